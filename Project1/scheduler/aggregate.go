@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// outputAggregate writes, for each algorithm, the mean of its average wait and average
+// turnaround over the results of every file, one list of results per file. Algorithms
+// are listed in the order the first file reported them.
+func outputAggregate(w io.Writer, perFile [][]Result) {
+	var titles []string
+	waits := make(map[string][]float64)
+	turnarounds := make(map[string][]float64)
+	for _, results := range perFile {
+		for _, res := range results {
+			if _, ok := waits[res.Title]; !ok {
+				titles = append(titles, res.Title)
+			}
+			waits[res.Title] = append(waits[res.Title], res.AverageWait())
+			turnarounds[res.Title] = append(turnarounds[res.Title], res.AverageTurnaround())
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Aggregate over %d files\n", len(perFile))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Files", "Mean average wait", "Mean average turnaround"})
+	for _, title := range titles {
+		table.Append([]string{
+			title,
+			fmt.Sprint(len(waits[title])),
+			fmt.Sprintf("%.2f", mean(waits[title])),
+			fmt.Sprintf("%.2f", mean(turnarounds[title])),
+		})
+	}
+	table.Render()
+}
+
+// mean returns the arithmetic mean of values, or 0 if there are none.
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_run_multipleFiles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	// FCFS waits an average of 3.33 with turnaround 10.00 on the first file, and 1.50
+	// with turnaround 4.50 on the second
+	first := filepath.Join(dir, "first.csv")
+	if err := os.WriteFile(first, []byte("1,5,0,2\n2,9,3,1\n3,6,6,3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	second := filepath.Join(dir, "second.csv")
+	if err := os.WriteFile(second, []byte("1,4,0,1\n2,2,1,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := Run([]string{"binary_name", "-algorithm=fcfs", first, second}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if got := strings.Count(out.String(), "Schedule table"); got != 2 {
+		t.Errorf("got %d schedules, want one per file:\n%s", got, out.String())
+	}
+	_, aggregate, found := strings.Cut(out.String(), "Aggregate over 2 files\n")
+	if !found {
+		t.Fatalf("output has no aggregate:\n%s", out.String())
+	}
+	if !regexp.MustCompile(`First-come, first-serve\s*\|\s*2\s*\|\s*2\.42\s*\|\s*7\.25\s*\|`).MatchString(aggregate) {
+		t.Errorf("aggregate missing the FCFS means over both files:\n%s", aggregate)
+	}
+
+	out.Reset()
+	if err := Run([]string{"binary_name", first, filepath.Join(dir, "missing.csv")}, &out); err == nil {
+		t.Error("run() error = nil, want an error for the missing file")
+	}
+	if out.Len() > 0 {
+		t.Errorf("wrote output before finding the missing file:\n%s", out.String())
+	}
+
+	// one JSON document per file would not parse as a whole
+	for _, flag := range []string{"-format=json", "-metrics-only"} {
+		if err := Run([]string{"binary_name", flag, first, second}, &out); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("run(%s) error = %v, want %v", flag, err, ErrInvalidArgs)
+		}
+	}
+
+	// the aggregate goes to the append file along with the schedules
+	out.Reset()
+	appended := filepath.Join(dir, "appended.txt")
+	if err := Run([]string{"binary_name", "-algorithm=fcfs", "-append=" + appended, first, second}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if out.Len() > 0 {
+		t.Errorf("wrote to stdout with -append:\n%s", out.String())
+	}
+	got, err := os.ReadFile(appended)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(got), "Schedule table"); n != 2 {
+		t.Errorf("append file has %d schedules, want one per file:\n%s", n, got)
+	}
+	if !strings.Contains(string(got), "Aggregate over 2 files\n") {
+		t.Errorf("append file has no aggregate:\n%s", got)
+	}
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// annotatedColumns is how many columns writeAnnotatedCSV adds for each result.
+const annotatedColumns = 3
+
+// writeAnnotatedCSV writes each process in the input CSV format, with every column
+// LoadProcesses reads filled in, followed by the wait, turnaround and completion time
+// computed by each result in turn. LoadProcesses reads the output back as the original
+// processes, ignoring the computed columns.
+func writeAnnotatedCSV(w io.Writer, processes []Process, results []Result) error {
+	cw := csv.NewWriter(w)
+	for i, p := range processes {
+		row := processFields(p)
+		for _, res := range results {
+			row = append(row,
+				strconv.FormatInt(res.Wait[i], 10),
+				strconv.FormatInt(res.Turnaround[i], 10),
+				strconv.FormatInt(res.Completion[i], 10),
+			)
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writeAnnotatedFile writes the annotated CSV for processes and results to the named file.
+func writeAnnotatedFile(name string, processes []Process, results []Result) error {
+	var b bytes.Buffer
+	if err := writeAnnotatedCSV(&b, processes, results); err != nil {
+		return fmt.Errorf("%w: encoding annotated CSV", err)
+	}
+	if err := os.WriteFile(name, b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("%v: error writing annotated CSV", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_writeAnnotatedCSV(t *testing.T) {
+	t.Parallel()
+	input := "1,5,0,2,0,\n2,9,3,1,20,\n3,6,6,3,0,A\n"
+	processes, err := LoadProcesses(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fcfsResult, sjfResult := FCFS(processes, Options{}), SJF(processes, Options{})
+
+	var out bytes.Buffer
+	if err := writeAnnotatedCSV(&out, processes, []Result{fcfsResult, sjfResult}); err != nil {
+		t.Fatalf("writeAnnotatedCSV() error = %v", err)
+	}
+	rows, err := csv.NewReader(bytes.NewReader(out.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatalf("reading annotated CSV: %v", err)
+	}
+
+	// the original fields padded to every input column, then wait, turnaround and
+	// completion for FCFS and then SJF
+	want := [][]string{
+		{"1", "5", "0", "2", "0", "", "0", "0", "0", "0", "0", "0", "5", "5", "0", "5", "5"},
+		{"2", "9", "3", "1", "20", "", "0", "0", "0", "0", "0", "2", "11", "14", "8", "17", "20"},
+		{"3", "6", "6", "3", "0", "A", "0", "0", "0", "0", "0", "8", "14", "20", "0", "6", "12"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("annotated CSV = %v, want %v", rows, want)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		got, err := LoadProcesses(bytes.NewReader(out.Bytes()))
+		if err != nil {
+			t.Fatalf("loadProcesses() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, processes) {
+			t.Errorf("loadProcesses() = %v, want %v", got, processes)
+		}
+	})
+}
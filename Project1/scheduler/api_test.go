@@ -0,0 +1,77 @@
+package scheduler_test
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Barritosaurus/CSCE4600/Project1/scheduler"
+)
+
+func TestSchedulersInMemory(t *testing.T) {
+	t.Parallel()
+	processes, err := scheduler.LoadProcesses(strings.NewReader("1,5,0,2\n2,9,3,1\n3,6,6,3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule func([]scheduler.Process, scheduler.Options) scheduler.Result
+		output   func(io.Writer, string, []scheduler.Process) scheduler.Result
+		wantWait []int64
+	}{
+		{name: "FCFS", schedule: scheduler.FCFS, output: scheduler.FCFSSchedule, wantWait: []int64{0, 2, 8}},
+		{name: "SJF", schedule: scheduler.SJF, output: scheduler.SJFSchedule, wantWait: []int64{0, 8, 0}},
+		{name: "SJF priority", schedule: scheduler.SJFPriority, output: scheduler.PriorityPreemptiveSchedule, wantWait: []int64{9, 0, 8}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.schedule(processes, scheduler.Options{})
+			if !reflect.DeepEqual(got.Wait, tt.wantWait) {
+				t.Errorf("Wait = %v, want %v", got.Wait, tt.wantWait)
+			}
+			// the output functions return the same result they write
+			if written := tt.output(io.Discard, tt.name, processes); !reflect.DeepEqual(written, got) {
+				t.Errorf("written result = %+v, want %+v", written, got)
+			}
+		})
+	}
+
+	t.Run("round-robin", func(t *testing.T) {
+		t.Parallel()
+		got, err := scheduler.RoundRobin(processes, scheduler.Options{})
+		if err != nil {
+			t.Fatalf("RoundRobin() error = %v", err)
+		}
+		if want := []int64{2, 8, 5}; !reflect.DeepEqual(got.Wait, want) {
+			t.Errorf("Wait = %v, want %v", got.Wait, want)
+		}
+		written, err := scheduler.RRSchedule(io.Discard, "round-robin", processes)
+		if err != nil {
+			t.Fatalf("RRSchedule() error = %v", err)
+		}
+		if !reflect.DeepEqual(written, got) {
+			t.Errorf("written result = %+v, want %+v", written, got)
+		}
+	})
+
+	t.Run("round-robin quantum", func(t *testing.T) {
+		t.Parallel()
+		// with a quantum of 4, P1 finishes in its second turn at 9, P3 at 19 and P2 at 20
+		got, err := scheduler.RRScheduleQuantum(io.Discard, "round-robin", processes, 4)
+		if err != nil {
+			t.Fatalf("RRScheduleQuantum() error = %v", err)
+		}
+		if want := []int64{4, 8, 7}; !reflect.DeepEqual(got.Wait, want) {
+			t.Errorf("Wait = %v, want %v", got.Wait, want)
+		}
+		if _, err := scheduler.RRScheduleQuantum(io.Discard, "round-robin", processes, 0); !errors.Is(err, scheduler.ErrInvalidArgs) {
+			t.Errorf("RRScheduleQuantum() with quantum 0 error = %v, want %v", err, scheduler.ErrInvalidArgs)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// bestQuantum runs round-robin with every quantum from 1 to the longest burst and
+// returns the one giving the lowest average turnaround, preferring the smallest
+// quantum on ties, along with that turnaround. It returns round-robin's error if the
+// processes cannot be scheduled.
+func bestQuantum(processes []Process, opts Options) (int64, float64, error) {
+	// the sweep is only to pick a quantum, so it has nothing to explain
+	opts.Explain = nil
+
+	var longest int64 = 1
+	for _, p := range processes {
+		if p.BurstDuration > longest {
+			longest = p.BurstDuration
+		}
+	}
+	var (
+		best       int64
+		turnaround float64
+	)
+	for q := int64(1); q <= longest; q++ {
+		opts.Quantum = q
+		res, err := RoundRobin(processes, opts)
+		if err != nil {
+			return 0, 0, err
+		}
+		if t := res.AverageTurnaround(); best == 0 || t < turnaround {
+			best, turnaround = q, t
+		}
+	}
+
+	return best, turnaround, nil
+}
+
+// outputAutoQuantum reports the quantum chosen by -auto-quantum.
+func outputAutoQuantum(w io.Writer, quantum int64, turnaround float64) {
+	_, _ = fmt.Fprintf(w, "Auto quantum: %d (lowest average turnaround %.2f)\n", quantum, turnaround)
+}
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_bestQuantum(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		processes      []Process
+		wantQuantum    int64
+		wantTurnaround float64
+	}{
+		{
+			// quantum 1 and 2 interleave P1 and P2 (average turnaround 7.67 and 8), while
+			// any quantum of 3 or more runs them in turn: P1 0-3, P2 3-6, P3 6-12
+			name: "smallest quantum that runs the short jobs whole",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1},
+				{ProcessID: 3, BurstDuration: 6, ArrivalTime: 2},
+			},
+			wantQuantum:    3,
+			wantTurnaround: 6,
+		},
+		{
+			// a quantum of 1 lets P3 and P2 finish before the long P1 (turnarounds 10, 6, 2)
+			name: "short jobs overtake a long one",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1},
+				{ProcessID: 3, BurstDuration: 1, ArrivalTime: 2},
+			},
+			wantQuantum:    1,
+			wantTurnaround: 6,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			quantum, turnaround, err := bestQuantum(tt.processes, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if quantum != tt.wantQuantum || round2(turnaround) != tt.wantTurnaround {
+				t.Errorf("bestQuantum() = %d, %.2f, want %d, %.2f", quantum, turnaround, tt.wantQuantum, tt.wantTurnaround)
+			}
+		})
+	}
+}
+
+func Test_run_autoQuantum(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "processes.csv")
+	if err := os.WriteFile(name, []byte("1,3,0\n2,3,1\n3,6,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := Run([]string{"scheduler", "-auto-quantum", name}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Auto quantum: 3 (lowest average turnaround 6.00)\n") {
+		t.Errorf("output missing the chosen quantum:\n%s", out.String())
+	}
+	// the round-robin schedule is the one for quantum 3
+	rrOutput := out.String()[strings.Index(out.String(), "Round-robin"):]
+	if !strings.Contains(rrOutput, "| 1 | 2 |  3   |\n0   3   6      12\n") {
+		t.Errorf("round-robin did not use quantum 3:\n%s", rrOutput)
+	}
+}
@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// PeakBacklog returns the most processes ever waiting in the ready queue at once: arrived
+// and unfinished, but not on any CPU.
+func (r Result) PeakBacklog() int {
+	var (
+		peak int
+		end  int
+		cpus = r.occupancies()
+	)
+	for _, pids := range cpus {
+		if len(pids) > end {
+			end = len(pids)
+		}
+	}
+	for t := 0; t < end; t++ {
+		running := make(map[int64]bool, len(cpus))
+		for _, pids := range cpus {
+			if t < len(pids) {
+				running[pids[t]] = true
+			}
+		}
+		var waiting int
+		for i, p := range r.Processes {
+			if p.ArrivalTime <= int64(t) && int64(t) < r.Completion[i] && !running[p.ProcessID] {
+				waiting++
+			}
+		}
+		if waiting > peak {
+			peak = waiting
+		}
+	}
+
+	return peak
+}
+
+// outputPeakBacklog writes the peak number of processes waiting at once.
+func outputPeakBacklog(w io.Writer, res Result) {
+	_, _ = fmt.Fprintf(w, "Peak backlog: %d waiting\n", res.PeakBacklog())
+}
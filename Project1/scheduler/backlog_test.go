@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"testing"
+)
+
+func Test_PeakBacklog(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      int
+	}{
+		{
+			// four processes arrive together while P1 holds the CPU
+			name: "simultaneous arrivals",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+				{ProcessID: 3, BurstDuration: 1, ArrivalTime: 1},
+				{ProcessID: 4, BurstDuration: 1, ArrivalTime: 1},
+				{ProcessID: 5, BurstDuration: 1, ArrivalTime: 1},
+			},
+			want: 4,
+		},
+		{
+			name: "never waiting",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 2},
+				{ProcessID: 3, BurstDuration: 1, ArrivalTime: 8},
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for _, schedule := range []func([]Process, Options) Result{FCFS, SJF, SJFPriority} {
+				if got := schedule(tt.processes, Options{}).PeakBacklog(); got != tt.want {
+					t.Errorf("PeakBacklog() = %d, want %d", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_PeakBacklog_cpus(t *testing.T) {
+	t.Parallel()
+	// four processes arrive together, two run and two wait
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 0},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 0},
+		{ProcessID: 4, BurstDuration: 2, ArrivalTime: 0},
+	}
+	for _, schedule := range []func([]Process, Options) Result{FCFS, SJF} {
+		if got := schedule(processes, Options{CPUs: 2}).PeakBacklog(); got != 2 {
+			t.Errorf("PeakBacklog() on 2 CPUs = %d, want 2", got)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// TurnaroundBounds is the range a process's turnaround can fall in under a schedule that
+// never idles while work is waiting and spends no time on setup.
+type TurnaroundBounds struct {
+	// Min is the CPU time the process gets: it never waits.
+	Min int64 `json:"min"`
+	// Max is the total work of every process: the CPU is busy from the process's arrival
+	// to its completion, and cannot be busy for longer than that.
+	Max int64 `json:"max"`
+}
+
+// turnaroundBounds returns the bounds on each process's turnaround.
+func turnaroundBounds(processes []Process) []TurnaroundBounds {
+	var work int64
+	for _, p := range processes {
+		work += p.cpuTime()
+	}
+	bounds := make([]TurnaroundBounds, len(processes))
+	for i, p := range processes {
+		bounds[i] = TurnaroundBounds{Min: p.cpuTime(), Max: work}
+	}
+
+	return bounds
+}
+
+// outputTurnaroundBounds writes each process's achieved turnaround between its bounds.
+func outputTurnaroundBounds(w io.Writer, res Result) {
+	_, _ = fmt.Fprintln(w, "Turnaround bounds")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Min", "Turnaround", "Max"})
+	for i, b := range turnaroundBounds(res.Processes) {
+		table.Append([]string{
+			fmt.Sprint(res.Processes[i].ProcessID),
+			fmt.Sprint(b.Min),
+			fmt.Sprint(res.Turnaround[i]),
+			fmt.Sprint(b.Max),
+		})
+	}
+	table.Render()
+}
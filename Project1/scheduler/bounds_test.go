@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_turnaroundBounds(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+	}{
+		{
+			name: "busy",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+				{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+			},
+		},
+		{
+			name: "idle gap",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0, Priority: 1},
+				{ProcessID: 2, BurstDuration: 2, ArrivalTime: 5, Priority: 2},
+				{ProcessID: 3, BurstDuration: 4, ArrivalTime: 6, Priority: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bounds := turnaroundBounds(tt.processes)
+			for _, res := range RunAll(tt.processes, Options{}) {
+				for i, p := range res.Processes {
+					if bounds[i].Min != p.BurstDuration {
+						t.Errorf("%s P%d min = %d, want the burst %d", res.Title, p.ProcessID, bounds[i].Min, p.BurstDuration)
+					}
+					if res.Turnaround[i] < bounds[i].Min || res.Turnaround[i] > bounds[i].Max {
+						t.Errorf("%s P%d turnaround %d outside [%d, %d]",
+							res.Title, p.ProcessID, res.Turnaround[i], bounds[i].Min, bounds[i].Max)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_outputResult_bounds(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+	}
+	var w bytes.Buffer
+	outputResult(&w, "First-come, first-serve", FCFS(processes, Options{}), config{bounds: true})
+	if want := "|  2 |   9 |         11 |  14 |"; !strings.Contains(w.String(), want) {
+		t.Errorf("output missing bounds row %q:\n%s", want, w.String())
+	}
+}
@@ -0,0 +1,21 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// sameAsFCFS reports whether gantt runs processes exactly as FCFS would, as happens
+// for SJF when every burst is equal or shorter jobs already arrive first.
+func sameAsFCFS(processes []Process, opts Options, gantt []TimeSlice) bool {
+	// the FCFS run is only for comparison, so it has nothing to explain
+	opts.Explain = nil
+
+	return reflect.DeepEqual(FCFS(processes, opts).Gantt, gantt)
+}
+
+// outputSameAsFCFS notes that a schedule coincides with FCFS on this input.
+func outputSameAsFCFS(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Note: no shorter job ever overtook an earlier arrival, so this schedule is the same as first-come, first-serve")
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_sameAsFCFS(t *testing.T) {
+	t.Parallel()
+	const note = "the same as first-come, first-serve"
+	tests := []struct {
+		name      string
+		processes []Process
+		want      bool
+	}{
+		{
+			name: "equal bursts",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 4, ArrivalTime: 1},
+				{ProcessID: 3, BurstDuration: 4, ArrivalTime: 2},
+			},
+			want: true,
+		},
+		{
+			name: "a shorter job overtakes",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3},
+				{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := SJF(tt.processes, Options{})
+			if got.SameAsFCFS != tt.want {
+				t.Fatalf("SameAsFCFS = %v, want %v", got.SameAsFCFS, tt.want)
+			}
+
+			var w bytes.Buffer
+			SJFSchedule(&w, "Shortest-job-first", tt.processes)
+			if strings.Contains(w.String(), note) != tt.want {
+				t.Errorf("note shown = %v, want %v:\n%s", !tt.want, tt.want, w.String())
+			}
+			if !tt.want {
+				return
+			}
+			want := FCFS(tt.processes, Options{})
+			if !reflect.DeepEqual(got.Gantt, want.Gantt) || !reflect.DeepEqual(got.Wait, want.Wait) ||
+				!reflect.DeepEqual(got.Turnaround, want.Turnaround) {
+				t.Errorf("SJF = %+v, want the FCFS result %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// ganttPalette holds the colors Gantt chart slices cycle through by PID. Red is left out,
+// as it marks processes that missed their deadline.
+var ganttPalette = []int{32, 33, 34, 35, 36, 92, 93, 94, 95, 96}
+
+// ansiDim colors idle time in the Gantt chart.
+const ansiDim = "\x1b[2;37m"
+
+// colorForPID returns the ANSI escape starting the color of the process with the given
+// ID in the Gantt chart, or dim gray for idle time.
+func colorForPID(pid int64) string {
+	if pid == idlePID {
+		return ansiDim
+	}
+	n := int64(len(ganttPalette))
+
+	return fmt.Sprintf("\x1b[%dm", ganttPalette[(pid%n+n)%n])
+}
+
+// isTerminal reports whether w writes to a terminal, where color escapes are shown as
+// color rather than left in the output.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_colorForPID(t *testing.T) {
+	t.Parallel()
+	if colorForPID(1) == colorForPID(2) {
+		t.Errorf("PIDs 1 and 2 share the color %q", colorForPID(1))
+	}
+	if n := int64(len(ganttPalette)); colorForPID(3) != colorForPID(3+n) {
+		t.Errorf("colors do not cycle through the palette every %d PIDs", n)
+	}
+	if colorForPID(idlePID) != ansiDim {
+		t.Errorf("idle color = %q, want dim gray", colorForPID(idlePID))
+	}
+	for pid := int64(0); pid < int64(len(ganttPalette)); pid++ {
+		if colorForPID(pid) == ansiRed {
+			t.Errorf("PID %d is colored red, which marks missed deadlines", pid)
+		}
+	}
+}
+
+func Test_run_color(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 5, BurstDuration: 2},
+	}
+	var colored bytes.Buffer
+	outputGantt(&colored, FCFS(processes, Options{}).Gantt, nil, config{color: true})
+	for _, want := range []string{colorForPID(1) + "1" + ansiReset, colorForPID(idlePID) + "idle" + ansiReset} {
+		if !strings.Contains(colored.String(), want) {
+			t.Errorf("colored Gantt chart missing %q:\n%q", want, colored.String())
+		}
+	}
+
+	// without -color, and with it when not writing to a terminal, nothing is colored
+	for _, args := range [][]string{
+		{"binary_name", "../example_processes.csv"},
+		{"binary_name", "-color", "../example_processes.csv"},
+	} {
+		var out bytes.Buffer
+		if err := Run(args, &out); err != nil {
+			t.Fatalf("run(%v) error = %v", args, err)
+		}
+		if strings.Contains(out.String(), "\x1b[") {
+			t.Errorf("run(%v) wrote escape codes:\n%q", args, out.String())
+		}
+	}
+}
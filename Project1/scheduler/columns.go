@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// processColumns is how many CSV columns a process can have.
+const processColumns = 11
+
+// requiredColumns is how many CSV columns a process must have: its pid, burst and arrival.
+const requiredColumns = 3
+
+// processFieldCount reports whether a CSV row of n fields holds a process: anywhere from
+// the required columns to every process column, or, as written by writeAnnotatedCSV,
+// every process column followed by annotatedColumns for each result, which are ignored.
+func processFieldCount(n int) bool {
+	if n > processColumns {
+		return (n-processColumns)%annotatedColumns == 0
+	}
+
+	return n >= requiredColumns
+}
+
+// columnNamed returns the CSV column a header or -columns name refers to, ignoring case,
+// spaces and underscores.
+func columnNamed(name string) (int, bool) {
+	col, ok := xlsxColumns[strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(strings.TrimSpace(name)))]
+	return col, ok
+}
+
+// parseColumns parses a comma separated column order such as "pid,arrival,burst" into
+// the CSV column each field holds. Every name must be known and used once, and the pid,
+// burst and arrival columns are required.
+func parseColumns(v string) ([]int, error) {
+	names := strings.Split(v, ",")
+	columns := make([]int, len(names))
+	var found [processColumns]bool
+	for i, name := range names {
+		col, ok := columnNamed(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidArgs, name)
+		}
+		if found[col] {
+			return nil, fmt.Errorf("%w: column %q given twice", ErrInvalidArgs, name)
+		}
+		found[col] = true
+		columns[i] = col
+	}
+	for col, name := range xlsxRequired {
+		if !found[col] {
+			return nil, fmt.Errorf("%w: columns have no %q", ErrInvalidArgs, name)
+		}
+	}
+
+	return columns, nil
+}
+
+// reorderRow lays out row, whose fields hold the given CSV columns, in CSV column order.
+// Columns of -1 are ignored, and missing or empty fields default to zero.
+func reorderRow(row []string, columns []int) []string {
+	fields := []string{"0", "0", "0", "0", "0", "", "0", "0", "0", "0", "0"}
+	for i, v := range row {
+		if i < len(columns) && columns[i] != -1 && v != "" {
+			fields[columns[i]] = v
+		}
+	}
+
+	return fields
+}
+
+// loadProcessesInOrder is LoadProcesses for files whose fields hold the given CSV columns.
+// As in CSV order, rows may leave off trailing columns that are not required.
+func loadProcessesInOrder(r io.Reader, columns []int) ([]Process, error) {
+	rows, first, err := readProcessRows(r)
+	if err != nil {
+		return nil, err
+	}
+	// a row must reach the last of the required columns
+	least := 0
+	for i, col := range columns {
+		if col < requiredColumns {
+			least = i + 1
+		}
+	}
+	for i := range rows {
+		if len(rows[i]) < least || len(rows[i]) > len(columns) {
+			return nil, fmt.Errorf("%w: row %d has %d fields, want %d to %d for the given columns",
+				ErrInvalidArgs, first+i, len(rows[i]), least, len(columns))
+		}
+		rows[i] = reorderRow(rows[i], columns)
+	}
+
+	return processesFromRows(rows, first)
+}
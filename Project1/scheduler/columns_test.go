@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadProcessesInOrder(t *testing.T) {
+	t.Parallel()
+	want, err := LoadProcesses(strings.NewReader("1,5,0,2\n2,9,3,1\n3,6,6,3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		columns string
+		input   string
+	}{
+		{name: "CSV order", columns: "pid,burst,arrival,priority", input: "1,5,0,2\n2,9,3,1\n3,6,6,3\n"},
+		{name: "arrival before burst", columns: "pid,arrival,burst,priority", input: "1,0,5,2\n2,3,9,1\n3,6,6,3\n"},
+		{name: "header names", columns: "Priority, Process ID, Arrival_Time, Burst", input: "2,1,0,5\n1,2,3,9\n3,3,6,6\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			columns, err := parseColumns(tt.columns)
+			if err != nil {
+				t.Fatalf("parseColumns() error = %v", err)
+			}
+			got, err := loadProcessesInOrder(strings.NewReader(tt.input), columns)
+			if err != nil {
+				t.Fatalf("loadProcessesInOrder() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("loadProcessesInOrder() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func Test_loadProcessesInOrder_fieldCount(t *testing.T) {
+	t.Parallel()
+	columns, err := parseColumns("pid,arrival,burst,priority")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "optional column left off", input: "1,0,5\n2,3,9,1\n"},
+		{name: "required column left off", input: "1,0,5,2\n2,3\n", wantErr: ErrInvalidArgs},
+		{name: "extra field", input: "1,0,5,2\n2,3,9,1,7\n", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := loadProcessesInOrder(strings.NewReader(tt.input), columns); !errors.Is(err, tt.wantErr) {
+				t.Errorf("loadProcessesInOrder() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parseColumns(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		columns string
+		want    []int
+		wantErr error
+	}{
+		{name: "reordered", columns: "pid,arrival,burst", want: []int{0, 2, 1}},
+		{name: "unknown", columns: "pid,arrival,burst,nice", wantErr: ErrInvalidArgs},
+		{name: "twice", columns: "pid,arrival,burst,arrival", wantErr: ErrInvalidArgs},
+		{name: "missing burst", columns: "pid,arrival,priority", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseColumns(tt.columns)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseColumns() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// comparisonMetric is a figure used to rank the results of different schedulers.
+type comparisonMetric struct {
+	name string
+	// value extracts the metric from a result.
+	value func(res Result) float64
+	// higherIsBetter is set for metrics where the largest value wins.
+	higherIsBetter bool
+	// count is set for metrics that are always whole numbers.
+	count bool
+}
+
+// format returns the metric's value for res as it is shown in comparison tables.
+func (m comparisonMetric) format(res Result) string {
+	if m.count {
+		return fmt.Sprintf("%.0f", m.value(res))
+	}
+
+	return fmt.Sprintf("%.2f", m.value(res))
+}
+
+// metricGroup is a category of related metrics, compared in a table of its own.
+type metricGroup struct {
+	name    string
+	metrics []comparisonMetric
+}
+
+// comparisonGroups lists the metrics schedulers are compared on, by category, in the
+// order they are reported.
+var comparisonGroups = []metricGroup{
+	{name: "Timing", metrics: []comparisonMetric{
+		{name: "Average wait", value: Result.AverageWait},
+		{name: "Average turnaround", value: Result.AverageTurnaround},
+		{name: "Average response", value: Result.AverageResponse},
+	}},
+	{name: "Efficiency", metrics: []comparisonMetric{
+		{name: "Utilization", value: Result.Utilization, higherIsBetter: true},
+		{name: "Throughput", value: func(res Result) float64 { return res.Throughput }, higherIsBetter: true},
+		{name: "Makespan", value: func(res Result) float64 {
+			return float64(Makespan(res.Processes, res.Gantt))
+		}, count: true},
+	}},
+	{name: "Overhead", metrics: []comparisonMetric{
+		{name: "Context switches", value: func(res Result) float64 { return float64(res.ContextSwitches()) }, count: true},
+		{name: "Preemptions", value: func(res Result) float64 { return float64(res.Preemptions()) }, count: true},
+	}},
+}
+
+// winnerTolerance is how close two metric values must be to count as a tie.
+const winnerTolerance = 1e-9
+
+// Winner names the schedulers that did best on a metric; ties list every one of them.
+type Winner struct {
+	Metric string
+	Titles []string
+}
+
+// winners returns the best scheduler(s) for each metric in comparisonGroups.
+func winners(results []Result) []Winner {
+	if len(results) == 0 {
+		return nil
+	}
+	var out []Winner
+	for _, group := range comparisonGroups {
+		for _, m := range group.metrics {
+			best := m.value(results[0])
+			for _, res := range results[1:] {
+				v := m.value(res)
+				if (m.higherIsBetter && v > best) || (!m.higherIsBetter && v < best) {
+					best = v
+				}
+			}
+			win := Winner{Metric: "Lowest " + strings.ToLower(m.name)}
+			if m.higherIsBetter {
+				win.Metric = "Highest " + strings.ToLower(m.name)
+			}
+			for _, res := range results {
+				if math.Abs(m.value(res)-best) <= winnerTolerance {
+					win.Titles = append(win.Titles, res.Title)
+				}
+			}
+			out = append(out, win)
+		}
+	}
+
+	return out
+}
+
+// outputWinners writes the winning scheduler(s) for each metric as a short list.
+func outputWinners(w io.Writer, results []Result) {
+	_, _ = fmt.Fprintln(w, "Winners")
+	for _, win := range winners(results) {
+		_, _ = fmt.Fprintf(w, "• %s: %s\n", win.Metric, strings.Join(win.Titles, ", "))
+	}
+}
+
+// outputGroupedComparison writes a table per metric group, comparing every scheduler on
+// the metrics in that group.
+func outputGroupedComparison(w io.Writer, results []Result) {
+	for _, group := range comparisonGroups {
+		_, _ = fmt.Fprintln(w, group.name)
+		table := tablewriter.NewWriter(w)
+		header := []string{"Algorithm"}
+		for _, m := range group.metrics {
+			header = append(header, m.name)
+		}
+		table.SetHeader(header)
+		for _, res := range results {
+			row := []string{res.Title}
+			for _, m := range group.metrics {
+				row = append(row, m.format(res))
+			}
+			table.Append(row)
+		}
+		table.Render()
+	}
+}
+
+// summaryMetrics are the metrics in the comparison table written after running every
+// algorithm.
+var summaryMetrics = []comparisonMetric{
+	{name: "Average wait", value: Result.AverageWait},
+	{name: "Average turnaround", value: Result.AverageTurnaround},
+	{name: "Throughput", value: func(res Result) float64 { return res.Throughput }},
+	{name: "Context switches", value: func(res Result) float64 { return float64(res.ContextSwitches()) }, count: true},
+}
+
+// outputComparison writes a table comparing the schedulers side by side, one row each.
+func outputComparison(w io.Writer, results []Result) {
+	_, _ = fmt.Fprintln(w, "Comparison")
+	table := tablewriter.NewWriter(w)
+	header := []string{"Algorithm"}
+	for _, m := range summaryMetrics {
+		header = append(header, m.name)
+	}
+	table.SetHeader(header)
+	for _, res := range results {
+		row := []string{res.Title}
+		for _, m := range summaryMetrics {
+			row = append(row, m.format(res))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_winners(t *testing.T) {
+	t.Parallel()
+	// the example input: average waits are FCFS 3.33, SJF 2.67, Priority 5.67 and RR 5.00,
+	// every schedule keeps the CPU busy until 20, and only FCFS never preempts
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+	}
+	all := []string{"First-come, first-serve", "Shortest-job-first", "Priority", "Round-robin"}
+
+	tests := []struct {
+		metric string
+		want   []string
+	}{
+		{metric: "Lowest average wait", want: []string{"Shortest-job-first"}},
+		{metric: "Lowest average turnaround", want: []string{"Shortest-job-first"}},
+		{metric: "Lowest average response", want: []string{"Shortest-job-first", "Round-robin"}},
+		{metric: "Highest utilization", want: all},
+		{metric: "Highest throughput", want: all},
+		{metric: "Lowest makespan", want: all},
+		{metric: "Lowest context switches", want: []string{"First-come, first-serve"}},
+		{metric: "Lowest preemptions", want: []string{"First-come, first-serve"}},
+	}
+
+	got := make(map[string][]string)
+	for _, win := range winners(RunAll(processes, Options{})) {
+		got[win.Metric] = win.Titles
+	}
+	if len(got) != len(tests) {
+		t.Fatalf("winners() covered %d metrics, want %d", len(got), len(tests))
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.metric, func(t *testing.T) {
+			t.Parallel()
+			if !reflect.DeepEqual(got[tt.metric], tt.want) {
+				t.Errorf("winners = %v, want %v", got[tt.metric], tt.want)
+			}
+		})
+	}
+}
+
+func Test_run_winners(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := Run([]string{"scheduler", "-winners", "../example_processes.csv"}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "• Lowest average wait: Shortest-job-first\n") {
+		t.Errorf("output missing the average wait winner:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "Schedule table") {
+		t.Errorf("output should hold only the winners:\n%s", out.String())
+	}
+}
+
+func Test_outputGroupedComparison(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+	}
+	results := RunAll(processes, Options{})
+	var out bytes.Buffer
+	outputGroupedComparison(&out, results)
+
+	tests := []struct {
+		group   string
+		metrics []string
+	}{
+		{group: "Timing", metrics: []string{"AVERAGE WAIT", "AVERAGE TURNAROUND", "AVERAGE RESPONSE"}},
+		{group: "Efficiency", metrics: []string{"UTILIZATION", "THROUGHPUT", "MAKESPAN"}},
+		{group: "Overhead", metrics: []string{"CONTEXT SWITCHES", "PREEMPTIONS"}},
+	}
+
+	// split the output into one section per group
+	sections := make(map[string]string)
+	var current string
+	for _, line := range strings.SplitAfter(out.String(), "\n") {
+		if name := strings.TrimSpace(line); !strings.ContainsAny(name, "|+") && name != "" {
+			current = name
+			continue
+		}
+		sections[current] += line
+	}
+	if len(sections) != len(tests) {
+		t.Fatalf("got %d groups, want %d:\n%s", len(sections), len(tests), out.String())
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.group, func(t *testing.T) {
+			t.Parallel()
+			section := sections[tt.group]
+			header := strings.Split(strings.Split(section, "\n")[1], "|")
+			var got []string
+			for _, cell := range header[2 : len(header)-1] {
+				got = append(got, strings.TrimSpace(cell))
+			}
+			if !reflect.DeepEqual(got, tt.metrics) {
+				t.Errorf("%s metrics = %v, want %v", tt.group, got, tt.metrics)
+			}
+			for _, res := range results {
+				if !strings.Contains(section, "| "+res.Title+" ") {
+					t.Errorf("%s table is missing %q:\n%s", tt.group, res.Title, section)
+				}
+			}
+		})
+	}
+}
+
+func Test_outputComparison(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := Run([]string{"binary_name", "-algorithm=all", "../example_processes.csv"}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	_, table, found := strings.Cut(out.String(), "Comparison\n")
+	if !found {
+		t.Fatalf("output has no comparison table:\n%s", out.String())
+	}
+
+	f, err := os.Open("../example_processes.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	processes, err := LoadProcesses(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := RunAll(processes, Options{})
+	if len(results) != 4 {
+		t.Fatalf("got %d default algorithms, want 4", len(results))
+	}
+	for _, res := range results {
+		row := ""
+		for _, line := range strings.Split(table, "\n") {
+			if strings.Contains(line, res.Title) {
+				row = line
+			}
+		}
+		if row == "" {
+			t.Errorf("comparison table has no row for %s:\n%s", res.Title, table)
+			continue
+		}
+		if cells := strings.Split(row, "|"); strings.TrimSpace(cells[2]) != fmt.Sprintf("%.2f", res.AverageWait()) {
+			t.Errorf("%s average wait cell = %q, want %.2f", res.Title, cells[2], res.AverageWait())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// convoyFactor is how many times the average burst a running job must be before the
+// short jobs waiting behind it count as a convoy.
+const convoyFactor = 2
+
+// Convoy records short jobs held up behind a much longer one.
+type Convoy struct {
+	// Leader is the long job that held the CPU.
+	Leader int64 `json:"leader"`
+	Burst  int64 `json:"burst"`
+	// Followers are the jobs with a below average burst that waited for the leader.
+	Followers []int64 `json:"followers"`
+}
+
+// convoys finds the convoy effect in a schedule: jobs with a below average burst that
+// arrived while a job of at least convoyFactor times the average burst held the CPU and
+// so had to wait for it.
+func convoys(processes []Process, gantt []TimeSlice) []Convoy {
+	if len(processes) == 0 {
+		return nil
+	}
+	var bursts int64
+	byPID := make(map[int64]Process, len(processes))
+	for i := range processes {
+		bursts += processes[i].BurstDuration
+		byPID[processes[i].ProcessID] = processes[i]
+	}
+	mean := float64(bursts) / float64(len(processes))
+
+	var out []Convoy
+	for _, slice := range gantt {
+		leader := byPID[slice.PID]
+		if float64(leader.BurstDuration) < convoyFactor*mean {
+			continue
+		}
+		c := Convoy{Leader: leader.ProcessID, Burst: leader.BurstDuration}
+		for i := range processes {
+			p := processes[i]
+			if float64(p.BurstDuration) < mean && p.ArrivalTime < slice.Stop && firstStart(gantt, p.ProcessID) >= slice.Stop {
+				c.Followers = append(c.Followers, p.ProcessID)
+			}
+		}
+		if len(c.Followers) > 0 {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// firstStart returns when the process with the given ID first ran, or -1 if it never did.
+func firstStart(gantt []TimeSlice, pid int64) int64 {
+	for _, slice := range gantt {
+		if slice.PID == pid {
+			return slice.Start
+		}
+	}
+
+	return -1
+}
+
+// outputConvoys warns about each convoy found in the schedule.
+func outputConvoys(w io.Writer, convoys []Convoy) {
+	for _, c := range convoys {
+		followers := make([]string, len(c.Followers))
+		for i, pid := range c.Followers {
+			followers[i] = fmt.Sprintf("P%d", pid)
+		}
+		_, _ = fmt.Fprintf(w, "Convoy effect: %s waited behind P%d (burst %d)\n",
+			strings.Join(followers, ", "), c.Leader, c.Burst)
+	}
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_convoys(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []Convoy
+		wantOut   string
+	}{
+		{
+			name: "short jobs behind a long one",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 20},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+				{ProcessID: 4, ArrivalTime: 3, BurstDuration: 3},
+			},
+			want:    []Convoy{{Leader: 1, Burst: 20, Followers: []int64{2, 3, 4}}},
+			wantOut: "Convoy effect: P2, P3, P4 waited behind P1 (burst 20)\n",
+		},
+		{
+			name: "short jobs arriving after the long one finishes",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 20},
+				{ProcessID: 2, ArrivalTime: 20, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 22, BurstDuration: 1},
+			},
+		},
+		{
+			name: "similar bursts",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+				{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := FCFS(tt.processes, Options{})
+			if !reflect.DeepEqual(res.Convoys, tt.want) {
+				t.Errorf("Convoys = %v, want %v", res.Convoys, tt.want)
+			}
+			var out bytes.Buffer
+			outputConvoys(&out, res.Convoys)
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("outputConvoys() = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
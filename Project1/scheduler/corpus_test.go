@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// corpusCase is a small hand-verified scenario with the expected metrics for each
+// algorithm, keyed by title. Averages are rounded to two decimal places.
+type corpusCase struct {
+	Name      string    `json:"name"`
+	Processes []Process `json:"processes"`
+	Expected  map[string]struct {
+		AverageWait       float64 `json:"average_wait"`
+		AverageTurnaround float64 `json:"average_turnaround"`
+	} `json:"expected"`
+}
+
+// loadCorpus reads the corpus of scenarios from a JSON file.
+func loadCorpus(t *testing.T, name string) []corpusCase {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("reading corpus: %v", err)
+	}
+	var corpus []corpusCase
+	if err := json.Unmarshal(b, &corpus); err != nil {
+		t.Fatalf("decoding corpus: %v", err)
+	}
+
+	return corpus
+}
+
+func Test_corpus(t *testing.T) {
+	t.Parallel()
+	corpus := loadCorpus(t, filepath.Join("testdata", "corpus.json"))
+	if len(corpus) == 0 {
+		t.Fatal("corpus is empty")
+	}
+
+	for _, tt := range corpus {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			results := RunAll(tt.Processes, Options{})
+			if len(tt.Expected) != len(results) {
+				t.Fatalf("case covers %d algorithms, want %d", len(tt.Expected), len(results))
+			}
+			for _, res := range results {
+				want, ok := tt.Expected[res.Title]
+				if !ok {
+					t.Errorf("no expectation for %s", res.Title)
+					continue
+				}
+				if got := round2(res.AverageWait()); got != want.AverageWait {
+					t.Errorf("%s average wait = %.2f, want %.2f", res.Title, got, want.AverageWait)
+				}
+				if got := round2(res.AverageTurnaround()); got != want.AverageTurnaround {
+					t.Errorf("%s average turnaround = %.2f, want %.2f", res.Title, got, want.AverageTurnaround)
+				}
+			}
+		})
+	}
+}
+
+// round2 rounds to two decimal places, the precision the corpus and the output use.
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// EDFSchedule writes the earliest-deadline-first schedule of the processes to w.
+func EDFSchedule(w io.Writer, title string, processes []Process) Result {
+	res := edf(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// edf is earliest-deadline-first: at every preemption point the arrived process with the
+// earliest absolute deadline runs, so an arrival due sooner preempts the running process.
+// Processes without a deadline run only when no process with one is ready. The running
+// process keeps the CPU on an equal deadline, and other ties are broken by selectNext.
+func edf(processes []Process, opts Options) Result {
+	return runPreemptive(processes, opts, "earliest deadline", func(i int, _ []int64) int64 {
+		return processes[i].Deadline
+	}, func(time int64, remaining []int64, running int) int {
+		return selectNext(processes, func(i int) bool {
+			return processes[i].ArrivalTime <= time && remaining[i] > 0 &&
+				!isSuspended(opts.Suspensions, processes[i].ProcessID, time)
+		}, func(a, b int) int {
+			if c := compareDeadlines(processes[a].Deadline, processes[b].Deadline); c != 0 {
+				return c
+			}
+			return preferRunning(running, a, b)
+		})
+	})
+}
+
+// compareDeadlines orders two deadlines, earliest first, with no deadline (0) last.
+func compareDeadlines(a, b int64) int {
+	switch {
+	case a == b:
+		return 0
+	case a == 0:
+		return 1
+	case b == 0:
+		return -1
+	}
+
+	return compareInt64(a, b)
+}
+
+// MissedDeadlines returns how many processes completed after their deadline.
+func (r Result) MissedDeadlines() int {
+	var missed int
+	for i := range r.Processes {
+		if r.MissedDeadline(i) {
+			missed++
+		}
+	}
+
+	return missed
+}
+
+// outputMissedDeadlines writes how many processes missed their deadline, if any process
+// has one.
+func outputMissedDeadlines(w io.Writer, res Result) {
+	for _, p := range res.Processes {
+		if p.Deadline > 0 {
+			_, _ = fmt.Fprintf(w, "Missed deadlines: %d\n", res.MissedDeadlines())
+			return
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package scheduler
+
+import "testing"
+
+func Test_compareDeadlines(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b int64
+		want int
+	}{
+		{a: 3, b: 5, want: -1},
+		{a: 5, b: 3, want: 1},
+		{a: 4, b: 4, want: 0},
+		{a: 0, b: 5, want: 1},
+		{a: 5, b: 0, want: -1},
+		{a: 0, b: 0, want: 0},
+	}
+	for _, tt := range tests {
+		if got := compareDeadlines(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareDeadlines(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"io"
+	"math"
+)
+
+// SRTFSchedule writes the shortest-remaining-time-first schedule of the processes to w.
+func SRTFSchedule(w io.Writer, title string, processes []Process) Result {
+	res := sjfEventDriven(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// sjfEventDriven computes the same schedule as SJF, but instead of stepping one tick at
+// a time it jumps straight to the next event: an arrival or the running process
+// finishing. The running process is only preempted by an arrival with strictly less
+// remaining time, and other ties are broken by selectNext, exactly as in SJF.
+//
+// This is shortest-remaining-time-first scheduling, and it runs as such with -with srtf
+// and SRTFSchedule; the convergence test in event_test.go checks it against SJF over many
+// random workloads so it can replace the tick loop safely.
+func sjfEventDriven(processes []Process, opts Options) Result {
+	var (
+		time        int64
+		finished    int
+		running     = -1
+		last        = -1
+		gantt       = make([]TimeSlice, 0)
+		remaining   = make([]int64, len(processes))
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+	)
+	for i := range processes {
+		remaining[i] = processes[i].cpuTime()
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+
+	for finished != len(processes) {
+		// pick the arrived process with the least remaining time, keeping the
+		// running one unless it is suspended or, at a preemption point, something
+		// strictly shorter is ready
+		if running != -1 && isSuspended(opts.Suspensions, processes[running].ProcessID, time) {
+			running = -1
+		}
+		next := running
+		if next == -1 || opts.preemptionPoint(time) {
+			next = selectNext(processes, func(i int) bool {
+				return processes[i].ArrivalTime <= time && remaining[i] > 0 &&
+					!isSuspended(opts.Suspensions, processes[i].ProcessID, time)
+			}, func(a, b int) int {
+				if c := compareInt64(remaining[a], remaining[b]); c != 0 {
+					return c
+				}
+				if c := preferRunning(running, a, b); c != 0 {
+					return c
+				}
+				return opts.tieBreak(processes, a, b)
+			})
+		}
+
+		// nothing ready: skip ahead to the next arrival or resumption
+		if next == -1 {
+			idle := nextEvent(processes, remaining, opts, time)
+			opts.onTicks(time, idle, idlePID, readyPIDs(processes, remaining, opts, time, idlePID))
+			time = idle
+			continue
+		}
+		running = next
+
+		// a powered down CPU has to wake, and switching class costs setup time,
+		// before the process can run
+		setup := opts.PowerDown.wakeDelay(gantt, time)
+		if last != -1 {
+			setup += opts.Setup.between(processes[last].Class, processes[running].Class)
+		}
+		opts.onTicks(time, time+setup, idlePID, readyPIDs(processes, remaining, opts, time, idlePID))
+		time += setup
+		last = running
+
+		// run until it finishes, is suspended, or reaches the next preemption point:
+		// every tick another process arrives or resumes, or else every granularity ticks
+		stop := time + remaining[running]
+		event := nextEvent(processes, remaining, opts, time)
+		if opts.PreemptGranularity > 1 {
+			event = opts.nextPreemptionPoint(time + 1)
+		}
+		if suspend := nextSuspension(opts.Suspensions, processes[running].ProcessID, time); suspend < event {
+			event = suspend
+		}
+		if event < stop {
+			stop = event
+		}
+		// anything that arrived during wake-up or setup is considered after the first tick
+		if setup > 0 && stop > time+1 {
+			stop = time + 1
+		}
+		pid := processes[running].ProcessID
+		opts.onTicks(time, stop, pid, readyPIDs(processes, remaining, opts, time, pid))
+		gantt = runSpan(gantt, pid, time, stop)
+		remaining[running] -= stop - time
+		time = stop
+
+		if remaining[running] == 0 {
+			finished++
+			completions[running] = time
+			turnArounds[running] = time - processes[running].ArrivalTime
+			waitTimes[running] = turnArounds[running] - processes[running].cpuTime() -
+				suspendedFor(opts.Suspensions, processes[running].ProcessID, processes[running].ArrivalTime, time)
+			running = -1
+		}
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+		SameAsFCFS: sameAsFCFS(processes, opts, gantt),
+	}
+}
+
+// nextArrival returns the earliest arrival after time among unfinished processes, or
+// math.MaxInt64 if there is none.
+func nextArrival(processes []Process, remaining []int64, time int64) int64 {
+	next := int64(math.MaxInt64)
+	for i := range processes {
+		if remaining[i] > 0 && processes[i].ArrivalTime > time && processes[i].ArrivalTime < next {
+			next = processes[i].ArrivalTime
+		}
+	}
+
+	return next
+}
+
+// nextEvent returns the next time after time that could change which process runs: an
+// arrival, or a process being suspended or resumed.
+func nextEvent(processes []Process, remaining []int64, opts Options, time int64) int64 {
+	next := nextArrival(processes, remaining, time)
+	if change := nextSuspensionChange(opts.Suspensions, time); change < next {
+		return change
+	}
+
+	return next
+}
+
+// runSpan records the process with the given ID running from start to stop, extending
+// its current slice if it was already running.
+func runSpan(gantt []TimeSlice, pid, start, stop int64) []TimeSlice {
+	if n := len(gantt); n > 0 && gantt[n-1].PID == pid && gantt[n-1].Stop == start {
+		gantt[n-1].Stop = stop
+		return gantt
+	}
+
+	return append(gantt, TimeSlice{PID: pid, Start: start, Stop: stop})
+}
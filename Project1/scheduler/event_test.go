@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// randomWorkload returns between 1 and 8 processes with small, possibly tied,
+// arrivals and bursts, listed in arrival order.
+func randomWorkload(rng *rand.Rand) []Process {
+	processes := make([]Process, 1+rng.Intn(8))
+	var arrival int64
+	for i := range processes {
+		// leave occasional idle gaps and frequent simultaneous arrivals
+		arrival += int64(rng.Intn(4))
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   arrival,
+			BurstDuration: 1 + int64(rng.Intn(10)),
+			Priority:      1 + int64(rng.Intn(5)),
+			Class:         string(rune('A' + rng.Intn(2))),
+		}
+	}
+
+	return processes
+}
+
+// assertConverges runs the legacy and optimized schedulers over seeded random workloads
+// and fails on the first workload where their results differ.
+func assertConverges(t *testing.T, legacy, optimized func([]Process, Options) Result, opts Options, workloads int) {
+	t.Helper()
+	for seed := int64(0); seed < int64(workloads); seed++ {
+		processes := randomWorkload(rand.New(rand.NewSource(seed)))
+		want := legacy(processes, opts)
+		got := optimized(processes, opts)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("seed %d: results differ for %+v\ngot  %+v\nwant %+v", seed, processes, got, want)
+		}
+	}
+}
+
+func Test_sjfEventDriven_converges(t *testing.T) {
+	t.Parallel()
+	assertConverges(t, SJF, sjfEventDriven, Options{}, 500)
+	assertConverges(t, SJF, sjfEventDriven, Options{Setup: SetupTimes{Default: 2}}, 500)
+	assertConverges(t, SJF, sjfEventDriven, Options{DispatchLatency: 3}, 500)
+	assertConverges(t, SJF, sjfEventDriven, Options{PowerDown: PowerDown{After: 2, Wake: 3}}, 500)
+	assertConverges(t, SJF, sjfEventDriven, Options{PreemptGranularity: 3}, 500)
+	assertConverges(t, SJF, sjfEventDriven, Options{PreemptGranularity: 4, Setup: SetupTimes{Default: 1}, Suspensions: []Suspension{
+		{PID: 1, From: 2, To: 5},
+		{PID: 2, From: 1, To: 7},
+	}}, 500)
+	assertConverges(t, SJF, sjfEventDriven, Options{Suspensions: []Suspension{
+		{PID: 1, From: 2, To: 5},
+		{PID: 2, From: 0, To: 3},
+		{PID: 3, From: 4, To: 9},
+		{PID: 3, From: 6, To: 12},
+	}}, 500)
+}
+
+func Test_srtf_preemptions(t *testing.T) {
+	t.Parallel()
+	// each arrival has less left to run than the process on the CPU, so P1 and then P2
+	// are preempted
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+		{ProcessID: 4, ArrivalTime: 10, BurstDuration: 5},
+	}
+	var out bytes.Buffer
+	got := SRTFSchedule(&out, "SRTF", processes)
+	if !strings.Contains(out.String(), "SRTF") {
+		t.Errorf("SRTFSchedule() output has no title:\n%s", out.String())
+	}
+
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 2},
+		{PID: 3, Start: 2, Stop: 3},
+		{PID: 2, Start: 3, Stop: 6},
+		{PID: 1, Start: 6, Stop: 13},
+		{PID: 4, Start: 13, Stop: 18},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", got.Gantt, want)
+	}
+	if wantWait := []int64{5, 1, 0, 3}; !reflect.DeepEqual(got.Wait, wantWait) {
+		t.Errorf("Wait = %v, want %v", got.Wait, wantWait)
+	}
+}
@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrFixtureMismatch is returned when a fixture no longer matches the output for its input.
+var ErrFixtureMismatch = errors.New("fixtures do not match the current output")
+
+// checkFixtures re-runs every scheduler on the input for each fixture in dir and reports
+// to w whether the output still matches. A fixture name.txt holds the default output for
+// the input name.csv beside it. Nothing in dir is modified.
+func checkFixtures(w io.Writer, dir string) error {
+	fixtures, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return err
+	}
+	if len(fixtures) == 0 {
+		return fmt.Errorf("%w: no fixtures in %s", ErrInvalidArgs, dir)
+	}
+
+	var failed int
+	for _, fixture := range fixtures {
+		matches, err := matchesFixture(fixture)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			failed++
+			_, _ = fmt.Fprintln(w, "FAIL", filepath.Base(fixture))
+			continue
+		}
+		_, _ = fmt.Fprintln(w, "ok  ", filepath.Base(fixture))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d failed", ErrFixtureMismatch, failed, len(fixtures))
+	}
+
+	return nil
+}
+
+// matchesFixture reports whether the output for the fixture's input equals the fixture.
+func matchesFixture(fixture string) (bool, error) {
+	want, err := os.ReadFile(fixture)
+	if err != nil {
+		return false, fmt.Errorf("%v: error reading fixture", err)
+	}
+	f, err := os.Open(strings.TrimSuffix(fixture, ".txt") + ".csv")
+	if err != nil {
+		return false, fmt.Errorf("%v: error opening fixture input", err)
+	}
+	defer f.Close()
+	processes, err := LoadProcesses(f)
+	if err != nil {
+		return false, err
+	}
+
+	var got bytes.Buffer
+	outputAll(&got, RunAll(processes, Options{}), config{})
+
+	return bytes.Equal(got.Bytes(), want), nil
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_checkFixtures(t *testing.T) {
+	t.Parallel()
+	input, err := os.ReadFile(filepath.Join("testdata", "fixtures", "example.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture, err := os.ReadFile(filepath.Join("testdata", "fixtures", "example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(fixture), "Makespan: 20", "Makespan: 21", 1)
+
+	tests := []struct {
+		name    string
+		fixture string
+		wantOut string
+		wantErr error
+	}{
+		{name: "matching", fixture: string(fixture), wantOut: "ok   example.txt\n"},
+		{name: "tampered", fixture: tampered, wantOut: "FAIL example.txt\n", wantErr: ErrFixtureMismatch},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "example.csv"), input, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "example.txt"), []byte(tt.fixture), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			err := Run([]string{"scheduler", "-check-fixtures", dir}, &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("run() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("run() output = %q, want %q", got, tt.wantOut)
+			}
+			// the check must leave the fixture untouched
+			if got, _ := os.ReadFile(filepath.Join(dir, "example.txt")); string(got) != tt.fixture {
+				t.Errorf("fixture was modified")
+			}
+		})
+	}
+}
+
+func Test_checkFixtures_testdata(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := checkFixtures(&out, filepath.Join("testdata", "fixtures")); err != nil {
+		t.Errorf("checkFixtures() error = %v\n%s", err, out.String())
+	}
+}
@@ -0,0 +1,20 @@
+package scheduler
+
+import "math"
+
+// preemptionPoint reports whether the preemptive schedulers may reconsider the running
+// process at time. With a granularity of G they only do so every G ticks, like a timer
+// interrupt; a process that finishes or is suspended still gives up the CPU at once.
+func (o Options) preemptionPoint(time int64) bool {
+	return o.PreemptGranularity <= 1 || time%o.PreemptGranularity == 0
+}
+
+// nextPreemptionPoint returns the first time at or after time at which the running
+// process may be preempted.
+func (o Options) nextPreemptionPoint(time int64) int64 {
+	if o.PreemptGranularity <= 1 || time > math.MaxInt64-o.PreemptGranularity {
+		return time
+	}
+
+	return (time + o.PreemptGranularity - 1) / o.PreemptGranularity * o.PreemptGranularity
+}
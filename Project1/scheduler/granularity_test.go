@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_preemptGranularity(t *testing.T) {
+	t.Parallel()
+	// short jobs arriving mid-burst preempt P1 straight away at granularity 1, but
+	// only at the next multiple of 4 at granularity 4
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 10, ArrivalTime: 0, Priority: 3},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 5, Priority: 2},
+	}
+	tests := []struct {
+		name     string
+		schedule scheduleFunc
+		fine     []TimeSlice
+		coarse   []TimeSlice
+	}{
+		{
+			name:     "SJF",
+			schedule: infallible(SJF),
+			fine: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 5},
+				{PID: 3, Start: 5, Stop: 7},
+				{PID: 1, Start: 7, Stop: 14},
+			},
+			coarse: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6},
+				{PID: 3, Start: 6, Stop: 8},
+				{PID: 1, Start: 8, Stop: 14},
+			},
+		},
+		{
+			name:     "SJF event-driven",
+			schedule: infallible(sjfEventDriven),
+			fine: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 5},
+				{PID: 3, Start: 5, Stop: 7},
+				{PID: 1, Start: 7, Stop: 14},
+			},
+			coarse: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6},
+				{PID: 3, Start: 6, Stop: 8},
+				{PID: 1, Start: 8, Stop: 14},
+			},
+		},
+		{
+			name:     "Priority",
+			schedule: infallible(SJFPriority),
+			fine: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 5},
+				{PID: 3, Start: 5, Stop: 7},
+				{PID: 1, Start: 7, Stop: 14},
+			},
+			coarse: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6},
+				{PID: 3, Start: 6, Stop: 8},
+				{PID: 1, Start: 8, Stop: 14},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fine := mustSchedule(t, tt.schedule, processes, Options{PreemptGranularity: 1})
+			coarse := mustSchedule(t, tt.schedule, processes, Options{PreemptGranularity: 4})
+			if !reflect.DeepEqual(fine.Gantt, tt.fine) {
+				t.Errorf("granularity 1 Gantt = %v, want %v", fine.Gantt, tt.fine)
+			}
+			if !reflect.DeepEqual(coarse.Gantt, tt.coarse) {
+				t.Errorf("granularity 4 Gantt = %v, want %v", coarse.Gantt, tt.coarse)
+			}
+			if coarse.Preemptions() >= fine.Preemptions() {
+				t.Errorf("preemptions at granularity 4 = %d, want fewer than %d at granularity 1",
+					coarse.Preemptions(), fine.Preemptions())
+			}
+		})
+	}
+}
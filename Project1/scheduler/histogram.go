@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// bucket counts the values from Low to High inclusive.
+type bucket struct {
+	Low, High int64
+	Count     int
+}
+
+// histogram sorts values into equal width buckets spanning their range. The number of
+// buckets follows Sturges' rule, so it grows slowly with the number of values.
+func histogram(values []int64) []bucket {
+	if len(values) == 0 {
+		return nil
+	}
+	low, high := values[0], values[0]
+	for _, v := range values {
+		if v < low {
+			low = v
+		}
+		if v > high {
+			high = v
+		}
+	}
+
+	n := int64(math.Ceil(math.Log2(float64(len(values))))) + 1
+	width := (high - low + n) / n // ceil((high-low+1)/n)
+	buckets := make([]bucket, 0, n)
+	for start := low; start <= high; start += width {
+		buckets = append(buckets, bucket{Low: start, High: start + width - 1})
+	}
+	for _, v := range values {
+		buckets[(v-low)/width].Count++
+	}
+
+	return buckets
+}
+
+// outputWaitHistogram writes a bar per bucket of waiting times.
+func outputWaitHistogram(w io.Writer, res Result) {
+	buckets := histogram(res.Wait)
+	labels := make([]string, len(buckets))
+	var labelWidth, barWidth int
+	for i, b := range buckets {
+		if b.Count > barWidth {
+			barWidth = b.Count
+		}
+		labels[i] = fmt.Sprintf("%d-%d", b.Low, b.High)
+		if len(labels[i]) > labelWidth {
+			labelWidth = len(labels[i])
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Wait histogram")
+	for i, b := range buckets {
+		_, _ = fmt.Fprintf(w, "%*s | %-*s %d\n", labelWidth, labels[i], barWidth, strings.Repeat("#", b.Count), b.Count)
+	}
+}
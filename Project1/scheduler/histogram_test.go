@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"testing"
+)
+
+func Test_histogram(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		values []int64
+	}{
+		{name: "single value", values: []int64{4}},
+		{name: "all equal", values: []int64{3, 3, 3, 3}},
+		{name: "spread", values: []int64{0, 2, 8, 1, 13, 5, 21, 3}},
+		{name: "negative and positive", values: []int64{-5, 0, 5, 10, 15}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			buckets := histogram(tt.values)
+			var total int
+			for i, b := range buckets {
+				total += b.Count
+				if i > 0 && b.Low != buckets[i-1].High+1 {
+					t.Errorf("bucket %d starts at %d, want %d", i, b.Low, buckets[i-1].High+1)
+				}
+			}
+			if total != len(tt.values) {
+				t.Errorf("bucket counts sum to %d, want %d", total, len(tt.values))
+			}
+		})
+	}
+
+	t.Run("every algorithm", func(t *testing.T) {
+		t.Parallel()
+		processes := []Process{
+			{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+			{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+			{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+			{ProcessID: 4, BurstDuration: 2, ArrivalTime: 7, Priority: 2},
+		}
+		for _, res := range RunAll(processes, Options{}) {
+			var total int
+			for _, b := range histogram(res.Wait) {
+				total += b.Count
+			}
+			if total != len(processes) {
+				t.Errorf("%s: bucket counts sum to %d, want %d", res.Title, total, len(processes))
+			}
+		}
+	})
+}
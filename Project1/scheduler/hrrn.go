@@ -0,0 +1,28 @@
+package scheduler
+
+import "io"
+
+// HRRNSchedule writes the highest-response-ratio-next schedule of the processes to w.
+func HRRNSchedule(w io.Writer, title string, processes []Process) Result {
+	res := hrrn(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// hrrn is highest-response-ratio-next: a non-preemptive schedule that runs the arrived
+// process with the greatest (waiting + burst) / burst. A long job's ratio keeps growing
+// while it waits, so unlike SJF it cannot be starved by a stream of short ones. Ties are
+// broken by selectNext.
+func hrrn(processes []Process, opts Options) Result {
+	return runToCompletion(processes, opts, func(time int64, remaining []int64, _ int) int {
+		return selectNext(processes, func(i int) bool {
+			return processes[i].ArrivalTime <= time && remaining[i] > 0
+		}, func(a, b int) int {
+			// compare the ratios by cross-multiplying, keeping to integers
+			ratioA := (time - processes[a].ArrivalTime + remaining[a]) * remaining[b]
+			ratioB := (time - processes[b].ArrivalTime + remaining[b]) * remaining[a]
+			return compareInt64(ratioB, ratioA)
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_hrrn(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+	}{
+		{
+			// P2 has waited as long as P3 but is twice as long, so P3 goes first
+			name: "short job wins on equal waits",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 6},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 3, Start: 4, Stop: 7},
+				{PID: 2, Start: 7, Stop: 13},
+			},
+		},
+		{
+			name: "idle until the next arrival",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 1},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 5, Stop: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := hrrn(tt.processes, Options{}); !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// computeIdleTime returns how long the CPU sat idle over a timeline total long that ends
+// with the last slice of gantt: the gaps between consecutive slices plus any gap before
+// the first.
+func computeIdleTime(gantt []TimeSlice, total int64) int64 {
+	idle := total
+	for _, slice := range gantt {
+		idle -= slice.Stop - slice.Start
+	}
+	if idle < 0 {
+		return 0
+	}
+
+	return idle
+}
+
+// IdleTime returns how long the CPUs sat idle between the first arrival and the end of
+// the schedule, adding up the idle time of each.
+func (r Result) IdleTime() int64 {
+	return computeIdleTime(r.Gantt, Makespan(r.Processes, r.Gantt)*int64(r.cpus()))
+}
+
+// outputUtilization writes the share of the makespan the CPU was busy and how long it
+// sat idle.
+func outputUtilization(w io.Writer, res Result) {
+	_, _ = fmt.Fprintf(w, "CPU utilization: %.2f%%\n", res.Utilization()*100)
+	_, _ = fmt.Fprintf(w, "Idle time: %d\n", res.IdleTime())
+}
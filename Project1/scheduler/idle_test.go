@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_computeIdleTime(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		total int64
+		want  int64
+	}{
+		{name: "empty", want: 0},
+		{
+			name:  "back to back",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}},
+			total: 5,
+			want:  0,
+		},
+		{
+			name:  "gap between two processes",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 5, Stop: 7}},
+			total: 7,
+			want:  2,
+		},
+		{
+			name:  "leading gap and a gap between",
+			gantt: []TimeSlice{{PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 6, Stop: 8}},
+			total: 8,
+			want:  5,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := computeIdleTime(tt.gantt, tt.total); got != tt.want {
+				t.Errorf("computeIdleTime() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_idleTime(t *testing.T) {
+	t.Parallel()
+	// P2 arrives two ticks after P1 finishes
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 5, BurstDuration: 2},
+	}
+	for _, res := range RunAll(processes, Options{}) {
+		if got := res.IdleTime(); got != 2 {
+			t.Errorf("%s idle time = %d, want 2", res.Title, got)
+		}
+		if got, want := res.Utilization(), 5.0/7; math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s utilization = %.2f, want %.2f", res.Title, got, want)
+		}
+	}
+}
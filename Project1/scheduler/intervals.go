@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RunningIntervals returns, for each process, the spans it held the CPU between its arrival
+// and completion, in order, joining slices that meet end to end. They come from the Gantt
+// chart, which records every segment as it is scheduled.
+func (r Result) RunningIntervals() [][]TimeSlice {
+	running := make([][]TimeSlice, len(r.Processes))
+	for i, p := range r.Processes {
+		for _, slice := range r.Gantt {
+			if slice.PID != p.ProcessID || slice.Start < p.ArrivalTime || slice.Stop > r.Completion[i] ||
+				slice.Start == slice.Stop {
+				continue
+			}
+			if n := len(running[i]); n > 0 && running[i][n-1].Stop == slice.Start {
+				running[i][n-1].Stop = slice.Stop
+				continue
+			}
+			running[i] = append(running[i], slice)
+		}
+	}
+
+	return running
+}
+
+// WaitingIntervals returns, for each process, the spans between its arrival and completion
+// it did not hold the CPU, including any time it was suspended.
+func (r Result) WaitingIntervals() [][]TimeSlice {
+	waiting := make([][]TimeSlice, len(r.Processes))
+	for i, running := range r.RunningIntervals() {
+		p := r.Processes[i]
+		from := p.ArrivalTime
+		for _, slice := range running {
+			if slice.Start > from {
+				waiting[i] = append(waiting[i], TimeSlice{PID: p.ProcessID, Start: from, Stop: slice.Start})
+			}
+			from = slice.Stop
+		}
+		if r.Completion[i] > from {
+			waiting[i] = append(waiting[i], TimeSlice{PID: p.ProcessID, Start: from, Stop: r.Completion[i]})
+		}
+	}
+
+	return waiting
+}
+
+// outputIntervals writes each process's running and waiting intervals as [start,stop] pairs.
+func outputIntervals(w io.Writer, res Result) {
+	running, waiting := res.RunningIntervals(), res.WaitingIntervals()
+	_, _ = fmt.Fprintln(w, "Intervals")
+	for i, p := range res.Processes {
+		_, _ = fmt.Fprintf(w, "P%d running %s waiting %s\n", p.ProcessID,
+			intervalsString(running[i]), intervalsString(waiting[i]))
+	}
+}
+
+// intervalsString lists slices as space separated [start,stop] pairs, or "none".
+func intervalsString(slices []TimeSlice) string {
+	if len(slices) == 0 {
+		return "none"
+	}
+	pairs := make([]string, len(slices))
+	for i, s := range slices {
+		pairs[i] = fmt.Sprintf("[%d,%d]", s.Start, s.Stop)
+	}
+
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_intervals(t *testing.T) {
+	t.Parallel()
+	// P2 arrives shorter than what P1 has left and preempts it
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 6, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1},
+	}
+	res := SJF(processes, Options{})
+
+	wantRunning := [][]TimeSlice{
+		{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 3, Stop: 8}},
+		{{PID: 2, Start: 1, Stop: 3}},
+	}
+	if got := res.RunningIntervals(); !reflect.DeepEqual(got, wantRunning) {
+		t.Errorf("RunningIntervals() = %v, want %v", got, wantRunning)
+	}
+	wantWaiting := [][]TimeSlice{
+		{{PID: 1, Start: 1, Stop: 3}},
+		nil,
+	}
+	if got := res.WaitingIntervals(); !reflect.DeepEqual(got, wantWaiting) {
+		t.Errorf("WaitingIntervals() = %v, want %v", got, wantWaiting)
+	}
+
+	var out bytes.Buffer
+	outputIntervals(&out, res)
+	want := "Intervals\nP1 running [0,1] [3,8] waiting [1,3]\nP2 running [1,3] waiting none\n"
+	if out.String() != want {
+		t.Errorf("outputIntervals() = %q, want %q", out.String(), want)
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		if err := Run([]string{"scheduler", "-algorithm=fcfs", "-intervals", "../example_processes.csv"}, &out); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if !strings.Contains(out.String(), "Intervals\nP1 running [0,5] waiting none\n") {
+			t.Errorf("Run() output has no intervals:\n%s", out.String())
+		}
+	})
+}
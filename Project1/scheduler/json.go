@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// loadProcessesJSON reads processes from a JSON array of objects with the fields pid,
+// arrival, burst and priority, and optionally deadline, class, weight, rate and quota.
+func loadProcessesJSON(r io.Reader) ([]Process, error) {
+	var processes []Process
+	if err := json.NewDecoder(r).Decode(&processes); err != nil {
+		return nil, fmt.Errorf("%w: decoding JSON processes", err)
+	}
+
+	return processes, nil
+}
+
+// inputFormatOf returns the input format named by the extension of the scheduling file,
+// or csv if the extension names none.
+func inputFormatOf(name string) string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+	if _, ok := inputLoaders[ext]; ok {
+		return ext
+	}
+
+	return "csv"
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadProcessesJSON(t *testing.T) {
+	t.Parallel()
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	tests := []struct {
+		name    string
+		input   string
+		want    []Process
+		wantErr interface{}
+	}{
+		{
+			name:  "success",
+			input: `[{"pid": 1, "arrival": 0, "burst": 5, "priority": 2}, {"pid": 2, "arrival": 3, "burst": 9, "priority": 1}]`,
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{name: "malformed", input: `[{"pid": 1,}]`, wantErr: &syntaxErr},
+		{name: "not a number", input: `[{"pid": "one"}]`, wantErr: &typeErr},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadProcessesJSON(strings.NewReader(tt.input))
+			if tt.wantErr != nil {
+				if !errors.As(err, tt.wantErr) {
+					t.Fatalf("loadProcessesJSON() error = %v, want %T", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadProcessesJSON() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProcessesJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_run_inputJSON(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	input := `[{"pid": 1, "burst": 5, "arrival": 0, "priority": 2},
+{"pid": 2, "burst": 9, "arrival": 3, "priority": 1},
+{"pid": 3, "burst": 6, "arrival": 6, "priority": 3}]`
+	// one file goes by its extension, the other by -input-format or its -input alias
+	byExtension := filepath.Join(dir, "processes.json")
+	byFlag := filepath.Join(dir, "processes.txt")
+	for _, name := range []string{byExtension, byFlag} {
+		if err := os.WriteFile(name, []byte(input), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var want bytes.Buffer
+	if err := Run([]string{"scheduler", "../example_processes.csv"}, &want); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	for _, args := range [][]string{
+		{"scheduler", byExtension},
+		{"scheduler", "-input-format", "json", byFlag},
+		{"scheduler", "-input", "json", byFlag},
+	} {
+		var got bytes.Buffer
+		if err := Run(args, &got); err != nil {
+			t.Fatalf("run(%q) error = %v", args, err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("run(%q) output differs from the CSV output:\n%s\nwant\n%s", args, got.String(), want.String())
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package scheduler
+
+import "io"
+
+// LJFSchedule writes the longest-job-first schedule of the processes to w.
+func LJFSchedule(w io.Writer, title string, processes []Process) Result {
+	res := ljf(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// ljf is longest-job-first, SJF's pathological counterpart: the arrived process with the
+// longest burst runs to completion before the next is picked, so short jobs wait behind
+// long ones. Ties are broken by selectNext.
+func ljf(processes []Process, opts Options) Result {
+	return runToCompletion(processes, opts, func(time int64, remaining []int64, _ int) int {
+		return selectNext(processes, func(i int) bool {
+			return processes[i].ArrivalTime <= time && remaining[i] > 0
+		}, func(a, b int) int {
+			return compareInt64(remaining[b], remaining[a])
+		})
+	})
+}
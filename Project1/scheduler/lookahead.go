@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"io"
+	"math"
+)
+
+// SJFNonPreemptiveSchedule writes the non-preemptive SJF schedule of the processes to w.
+func SJFNonPreemptiveSchedule(w io.Writer, title string, processes []Process) Result {
+	res := sjfNonPreemptive(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// sjfLookahead is non-preemptive SJF that breaks ties between equally short jobs with a
+// one-step lookahead: it runs whichever lets the job after it start soonest, which can
+// save a setup time for a job about to arrive. The lookahead treats a process suspended
+// now as out of contention.
+func sjfLookahead(processes []Process, opts Options) Result {
+	return runToCompletion(processes, opts, func(time int64, remaining []int64, last int) int {
+		next := shortestArrived(processes, remaining, time, -1)
+		if next == -1 {
+			return -1
+		}
+		best := int64(math.MaxInt64)
+		for i := range processes {
+			if processes[i].ArrivalTime > time || remaining[i] == 0 || remaining[i] != remaining[next] {
+				continue
+			}
+			if start := followingStart(processes, remaining, opts.Setup, last, i, time); start < best {
+				best, next = start, i
+			}
+		}
+
+		return next
+	})
+}
+
+// sjfNonPreemptive is textbook shortest-job-first: the arrived process with the shortest
+// burst runs to completion before the next is picked, so each process runs in one slice.
+// Ties are broken by selectNext.
+func sjfNonPreemptive(processes []Process, opts Options) Result {
+	return runToCompletion(processes, opts, func(time int64, remaining []int64, _ int) int {
+		return shortestArrived(processes, remaining, time, -1)
+	})
+}
+
+// shortestArrived returns the unfinished process arrived by time with the least remaining
+// time, other than skip, or -1 if there is none. Ties are broken by selectNext.
+func shortestArrived(processes []Process, remaining []int64, time int64, skip int) int {
+	return selectNext(processes, func(i int) bool {
+		return i != skip && processes[i].ArrivalTime <= time && remaining[i] > 0
+	}, func(a, b int) int {
+		return compareInt64(remaining[a], remaining[b])
+	})
+}
+
+// followingStart returns when the process after candidate could start if candidate ran
+// next, after last, from time: once candidate finishes, the shortest process then arrived
+// or else the next to arrive, plus any setup time to switch to it.
+func followingStart(processes []Process, remaining []int64, setup SetupTimes, last, candidate int, time int64) int64 {
+	finish := time + remaining[candidate]
+	if last != -1 {
+		finish += setup.between(processes[last].Class, processes[candidate].Class)
+	}
+	following := shortestArrived(processes, remaining, finish, candidate)
+	if following == -1 {
+		// nothing is waiting, so the next arrival is what would wait on candidate
+		arrival := int64(math.MaxInt64)
+		for i := range processes {
+			if i != candidate && remaining[i] > 0 && processes[i].ArrivalTime < arrival {
+				arrival, following = processes[i].ArrivalTime, i
+			}
+		}
+		if following == -1 {
+			return finish
+		}
+		finish = arrival
+	}
+
+	return finish + setup.between(processes[candidate].Class, processes[following].Class)
+}
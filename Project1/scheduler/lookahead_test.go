@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_sjfLookahead(t *testing.T) {
+	t.Parallel()
+	// at time 2, P2 and P3 tie on burst; P4 arrives while either runs and is a costly
+	// setup after class A but a cheap one after class B
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Class: "A"},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Class: "A"},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3, Class: "B"},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 1, Class: "C"},
+	}
+	opts := Options{Setup: SetupTimes{Matrix: map[string]map[string]int64{
+		"A": {"B": 1, "C": 5},
+		"B": {"C": 1},
+	}}}
+
+	plain := sjfNonPreemptive(processes, opts)
+	got := sjfLookahead(processes, opts)
+
+	// the default tie-break runs P2 first, the lookahead runs P3
+	if plain.Gantt[1].PID != 2 {
+		t.Errorf("default tie-break ran P%d second, want P2", plain.Gantt[1].PID)
+	}
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 3, Start: 3, Stop: 6},
+		{PID: 4, Start: 7, Stop: 8},
+		{PID: 2, Start: 8, Stop: 11},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", got.Gantt, want)
+	}
+	if avg, base := average(got.Wait), average(plain.Wait); avg >= base {
+		t.Errorf("average wait = %.2f, want less than the default tie-break's %.2f", avg, base)
+	}
+}
+
+func Test_sjfNonPreemptive(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+	}{
+		{
+			// P1 keeps the CPU while shorter jobs arrive, and the tie between P3 and
+			// P4 goes to the earlier arrival
+			name: "long job first",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 6},
+				{ProcessID: 4, ArrivalTime: 2, BurstDuration: 3},
+				{ProcessID: 3, ArrivalTime: 3, BurstDuration: 3},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 10},
+				{PID: 4, Start: 10, Stop: 13},
+				{PID: 3, Start: 13, Stop: 16},
+				{PID: 2, Start: 16, Stop: 22},
+			},
+		},
+		{
+			name: "simultaneous arrivals tie on PID",
+			processes: []Process{
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 4, Stop: 8},
+			},
+		},
+		{
+			name: "idle gap",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 9, BurstDuration: 1},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 2, Stop: 5},
+				{PID: 2, Start: 9, Stop: 10},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := SJFNonPreemptiveSchedule(io.Discard, "Shortest-job-first (non-preemptive)", tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.want)
+			}
+		})
+	}
+}
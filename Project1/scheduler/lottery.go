@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// LotterySchedule writes the lottery schedule of the processes, drawn with seed, to w.
+func LotterySchedule(w io.Writer, title string, processes []Process, seed int64) Result {
+	res := lottery(processes, Options{LotterySeed: seed})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// lottery draws a winning ticket among the ready processes at the start of every quantum,
+// each process holding as many tickets as its Tickets column, and runs the winner for up to
+// a round-robin quantum. The drawings come from a PRNG seeded with Options.LotterySeed, so
+// the same seed always gives the same schedule. Every drawing starts a new Gantt slice, so
+// the slices are the winners in turn, and Result.Wins counts each process's wins.
+func lottery(processes []Process, opts Options) Result {
+	var (
+		time        int64
+		finished    int
+		last        = -1
+		tq          = opts.quantum()
+		draw        = rand.New(rand.NewSource(opts.LotterySeed))
+		gantt       = make([]TimeSlice, 0)
+		remaining   = make([]int64, len(processes))
+		wins        = make([]int64, len(processes))
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+	)
+	for i := range processes {
+		remaining[i] = processes[i].cpuTime()
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+
+	// ready reports whether the process at index i can take part in a drawing now
+	ready := func(i int) bool {
+		return processes[i].ArrivalTime <= time && remaining[i] > 0 &&
+			!isSuspended(opts.Suspensions, processes[i].ProcessID, time)
+	}
+
+	for finished != len(processes) {
+		var total int64
+		for i := range processes {
+			if ready(i) {
+				total += processes[i].tickets()
+			}
+		}
+		if total == 0 {
+			opts.onTicks(time, time+1, idlePID, readyPIDs(processes, remaining, opts, time, idlePID))
+			time++
+			continue
+		}
+
+		// walk the ready processes in list order to the holder of the winning ticket
+		ticket := draw.Int63n(total)
+		next := -1
+		for i, held := range processes {
+			if !ready(i) {
+				continue
+			}
+			if ticket < held.tickets() {
+				next = i
+				break
+			}
+			ticket -= held.tickets()
+		}
+		wins[next]++
+
+		if opts.Explain != nil {
+			explainDispatch(opts.Explain, time, processes[next].ProcessID,
+				fmt.Sprintf("won the drawing (%d of %d tickets)", processes[next].tickets(), total),
+				readyLabels(processes, remaining, time, func(i int) string {
+					return fmt.Sprintf("P%d:%d", processes[i].ProcessID, processes[i].tickets())
+				}))
+		}
+
+		// a powered down CPU has to wake, and switching class costs setup time,
+		// before the process can run
+		time += opts.PowerDown.wakeDelay(gantt, time)
+		if last != -1 {
+			time += opts.Setup.between(processes[last].Class, processes[next].Class)
+		}
+		last = next
+
+		gantt = append(gantt, TimeSlice{PID: processes[next].ProcessID, Start: time, Stop: time})
+		for used := int64(0); used < tq && remaining[next] > 0 &&
+			!isSuspended(opts.Suspensions, processes[next].ProcessID, time); used++ {
+			pid := processes[next].ProcessID
+			opts.onTicks(time, time+1, pid, readyPIDs(processes, remaining, opts, time, pid))
+			gantt[len(gantt)-1].Stop++
+			remaining[next]--
+			time++
+		}
+		// a winner suspended while the CPU woke or was set up gets no time
+		if g := gantt[len(gantt)-1]; g.Start == g.Stop {
+			gantt = gantt[:len(gantt)-1]
+		}
+
+		if remaining[next] == 0 {
+			finished++
+			completions[next] = time
+			turnArounds[next] = time - processes[next].ArrivalTime
+			waitTimes[next] = turnArounds[next] - processes[next].cpuTime() -
+				suspendedFor(opts.Suspensions, processes[next].ProcessID, processes[next].ArrivalTime, time)
+		}
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+		Wins:       wins,
+	}
+}
+
+// tickets returns the process's lottery tickets, counting an unset count as 1.
+func (p Process) tickets() int64 {
+	if p.Tickets <= 0 {
+		return 1
+	}
+
+	return p.Tickets
+}
+
+// outputWins writes how many lottery drawings each process won, next to its tickets.
+func outputWins(w io.Writer, res Result) {
+	_, _ = fmt.Fprintln(w, "Lottery wins")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Tickets", "Wins"})
+	for i, p := range res.Processes {
+		table.Append([]string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.tickets()),
+			fmt.Sprint(res.Wins[i]),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+import "io"
+
+// LRTFSchedule writes the longest-remaining-time-first schedule of the processes to w.
+func LRTFSchedule(w io.Writer, title string, processes []Process) Result {
+	res := lrtf(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// lrtf is longest-remaining-time-first, the preemptive form of LJF: at every preemption
+// point the arrived process with the most remaining time runs, so the running process is
+// overtaken as soon as it drops below another and the CPU switches far more often than
+// under any other algorithm. Ties are broken by selectNext.
+func lrtf(processes []Process, opts Options) Result {
+	return runPreemptive(processes, opts, "longest remaining", func(i int, remaining []int64) int64 {
+		return remaining[i]
+	}, func(time int64, remaining []int64, _ int) int {
+		return longestArrived(processes, remaining, opts, time)
+	})
+}
+
+// longestArrived returns the unfinished, unsuspended process arrived by time with the most
+// remaining time, or -1 if there is none. Ties are broken by selectNext.
+func longestArrived(processes []Process, remaining []int64, opts Options, time int64) int {
+	return selectNext(processes, func(i int) bool {
+		return processes[i].ArrivalTime <= time && remaining[i] > 0 &&
+			!isSuspended(opts.Suspensions, processes[i].ProcessID, time)
+	}, func(a, b int) int {
+		return compareInt64(remaining[b], remaining[a])
+	})
+}
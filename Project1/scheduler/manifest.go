@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// version identifies the build in run manifests; override it with
+// -ldflags "-X main.version=..." when building a release.
+var version = "dev"
+
+// Manifest ties a set of results to the exact input and options that produced them.
+type Manifest struct {
+	Version     string            `json:"version"`
+	Input       string            `json:"input"`
+	InputSHA256 string            `json:"input_sha256"`
+	Options     map[string]string `json:"options"`
+}
+
+// writeManifest writes the manifest for a run over the named input to cfg.manifestPath.
+func writeManifest(cfg config, input string, inputSum []byte) error {
+	b, err := json.MarshalIndent(Manifest{
+		Version:     version,
+		Input:       input,
+		InputSHA256: hex.EncodeToString(inputSum),
+		Options:     cfg.flags,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: encoding manifest", err)
+	}
+	if err := os.WriteFile(cfg.manifestPath, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("%v: error writing manifest", err)
+	}
+
+	return nil
+}
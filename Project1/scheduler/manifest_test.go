@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"testing"
+)
+
+func Test_run_manifest(t *testing.T) {
+	dir := t.TempDir()
+	input := path.Join(dir, "input.csv")
+	contents := []byte("1,5,0,2\n2,9,3,1\n3,6,6,3\n")
+	if err := os.WriteFile(input, contents, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out := path.Join(dir, "run.json")
+
+	if err := Run([]string{"binary_name", "-manifest", out, "-tabular-gantt", input}, io.Discard); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Manifest
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+
+	sum := sha256.Sum256(contents)
+	if want := hex.EncodeToString(sum[:]); got.InputSHA256 != want {
+		t.Errorf("InputSHA256 = %v, want %v", got.InputSHA256, want)
+	}
+	if got.Input != input {
+		t.Errorf("Input = %v, want %v", got.Input, input)
+	}
+	if got.Version != version {
+		t.Errorf("Version = %v, want %v", got.Version, version)
+	}
+	wantOptions := map[string]string{
+		"manifest":      out,
+		"tabular-gantt": "true",
+		"check-sorted":  "false",
+	}
+	for name, want := range wantOptions {
+		if got.Options[name] != want {
+			t.Errorf("Options[%q] = %q, want %q", name, got.Options[name], want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// outputMarkdownTitle writes the title of an algorithm's results as a Markdown heading.
+func outputMarkdownTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintf(w, "## %s\n\n", title)
+}
+
+// outputMarkdownGantt writes the Gantt chart as a Markdown table of slices, with a row for
+// each gap where the CPU idled.
+func outputMarkdownGantt(w io.Writer, gantt []TimeSlice) {
+	_, _ = fmt.Fprintln(w, "### Gantt schedule")
+	_, _ = fmt.Fprintln(w)
+	rows := make([][]string, 0, len(gantt))
+	for _, slice := range withIdleSlices(gantt) {
+		rows = append(rows, []string{
+			ganttLabel(slice.PID),
+			fmt.Sprint(slice.Start),
+			fmt.Sprint(slice.Stop),
+			fmt.Sprint(slice.Stop - slice.Start),
+		})
+	}
+	outputMarkdownTable(w, []string{"PID", "Start", "Stop", "Duration"}, rows)
+}
+
+// outputMarkdownSchedule writes the schedule table in Markdown, with the footer as a last
+// row of averages.
+func outputMarkdownSchedule(w io.Writer, rows [][]string, footer []string) {
+	_, _ = fmt.Fprintln(w, "### Schedule table")
+	_, _ = fmt.Fprintln(w)
+	last := make([]string, len(footer))
+	for i := range footer {
+		last[i] = strings.ReplaceAll(footer[i], "\n", " ")
+	}
+	outputMarkdownTable(w, scheduleColumns, append(rows[:len(rows):len(rows)], last))
+}
+
+// outputMarkdownTable writes a GitHub-flavored Markdown table, followed by a blank line.
+func outputMarkdownTable(w io.Writer, header []string, rows [][]string) {
+	_, _ = fmt.Fprintf(w, "| %s |\n", strings.Join(header, " | "))
+	_, _ = fmt.Fprintf(w, "|%s\n", strings.Repeat("---|", len(header)))
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(w, "| %s |\n", strings.Join(row, " | "))
+	}
+	_, _ = fmt.Fprintln(w)
+}
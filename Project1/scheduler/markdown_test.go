@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_run_markdown(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := Run([]string{"binary_name", "-format=markdown", "-algorithm=fcfs", "../example_processes.csv"}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	got := out.String()
+
+	for _, want := range []string{
+		"## First-come, first-serve\n",
+		"| PID | Start | Stop | Duration |\n|---|---|---|---|\n| 1 | 0 | 5 | 5 |\n",
+		"| ID | Priority | Burst | Arrival | Wait | Turnaround | Response | Response ratio | Normalized turnaround | Exit |\n" +
+			"|---|---|---|---|---|---|---|---|---|---|\n",
+		"|  |  |  |  | Average 3.33 | Average 10.00 | Average 3.33 | Average 1.52 | Average 1.52 | Throughput 0.15/t |\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "+--") {
+		t.Errorf("output has ASCII table borders:\n%s", got)
+	}
+}
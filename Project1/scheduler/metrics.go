@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Metrics is the machine-readable summary of a result written by -metrics-only.
+type Metrics struct {
+	Title             string  `json:"title"`
+	AverageWait       float64 `json:"average_wait"`
+	AverageTurnaround float64 `json:"average_turnaround"`
+	AverageResponse   float64 `json:"average_response"`
+	AverageStretch    float64 `json:"average_stretch"`
+	Utilization       float64 `json:"utilization"`
+	Throughput        float64 `json:"throughput"`
+	Makespan          int64   `json:"makespan"`
+	PeakBacklog       int     `json:"peak_backlog"`
+}
+
+// ProcessMetrics holds the scheduling metrics of a single process.
+type ProcessMetrics struct {
+	PID        int64 `json:"pid"`
+	Wait       int64 `json:"wait"`
+	Turnaround int64 `json:"turnaround"`
+	Completion int64 `json:"completion"`
+	Response   int64 `json:"response"`
+}
+
+// ProcessMetrics returns the metrics of each process, in the order the processes are listed.
+func (r Result) ProcessMetrics() []ProcessMetrics {
+	response := r.ResponseTimes()
+	out := make([]ProcessMetrics, len(r.Processes))
+	for i, p := range r.Processes {
+		out[i] = ProcessMetrics{
+			PID:        p.ProcessID,
+			Wait:       r.Wait[i],
+			Turnaround: r.Turnaround[i],
+			Completion: r.Completion[i],
+			Response:   response[i],
+		}
+	}
+
+	return out
+}
+
+// FCFSScheduleWithMetrics is FCFSSchedule returning each process's metrics, or an error if
+// the processes cannot be scheduled.
+func FCFSScheduleWithMetrics(w io.Writer, title string, processes []Process) ([]ProcessMetrics, error) {
+	return scheduleWithMetrics(w, title, processes, infallible(FCFS))
+}
+
+// SJFScheduleWithMetrics is SJFSchedule returning each process's metrics, or an error if
+// the processes cannot be scheduled.
+func SJFScheduleWithMetrics(w io.Writer, title string, processes []Process) ([]ProcessMetrics, error) {
+	return scheduleWithMetrics(w, title, processes, infallible(SJF))
+}
+
+// SJFPriorityScheduleWithMetrics is PriorityPreemptiveSchedule returning each process's
+// metrics, or an error if the processes cannot be scheduled.
+func SJFPriorityScheduleWithMetrics(w io.Writer, title string, processes []Process) ([]ProcessMetrics, error) {
+	return scheduleWithMetrics(w, title, processes, infallible(SJFPriority))
+}
+
+// RRScheduleWithMetrics is RRSchedule returning each process's metrics, or an error if the
+// processes cannot be scheduled.
+func RRScheduleWithMetrics(w io.Writer, title string, processes []Process) ([]ProcessMetrics, error) {
+	return scheduleWithMetrics(w, title, processes, RoundRobin)
+}
+
+// scheduleWithMetrics checks the processes, then schedules them and writes the titled
+// result to w, returning each process's metrics.
+func scheduleWithMetrics(w io.Writer, title string, processes []Process, schedule scheduleFunc) ([]ProcessMetrics, error) {
+	if err := validateProcesses(processes); err != nil {
+		return nil, err
+	}
+	res, err := schedule(processes, Options{})
+	if err != nil {
+		return nil, err
+	}
+	outputResult(w, title, res, config{})
+
+	return res.ProcessMetrics(), nil
+}
+
+// summarize returns the key metrics of each result.
+func summarize(results []Result) []Metrics {
+	out := make([]Metrics, len(results))
+	for i, res := range results {
+		out[i] = Metrics{
+			Title:             res.Title,
+			AverageWait:       res.AverageWait(),
+			AverageTurnaround: res.AverageTurnaround(),
+			AverageResponse:   res.AverageResponse(),
+			AverageStretch:    res.AverageStretch(),
+			Utilization:       res.Utilization(),
+			Throughput:        res.Throughput,
+			Makespan:          Makespan(res.Processes, res.Gantt),
+			PeakBacklog:       res.PeakBacklog(),
+		}
+	}
+
+	return out
+}
+
+// writeMetricsLine writes the metrics of every result to w as a single line of JSON.
+func writeMetricsLine(w io.Writer, results []Result) error {
+	// json.Encoder ends the value with a newline and never breaks it across lines
+	if err := json.NewEncoder(w).Encode(summarize(results)); err != nil {
+		return fmt.Errorf("%w: encoding metrics", err)
+	}
+
+	return nil
+}
+
+// writeFullFile writes the human-readable output held back by -metrics-only to name.
+func writeFullFile(name string, full []byte) error {
+	if err := os.WriteFile(name, full, 0o644); err != nil {
+		return fmt.Errorf("%v: error writing full output", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_run_metricsOnly(t *testing.T) {
+	t.Parallel()
+	fullPath := filepath.Join(t.TempDir(), "out.txt")
+	var out bytes.Buffer
+	if err := Run([]string{"scheduler", "-metrics-only", "-full", fullPath, "../example_processes.csv"}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	if lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"); len(lines) != 1 {
+		t.Fatalf("stdout has %d lines, want 1:\n%s", len(lines), out.String())
+	}
+	var got []Metrics
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, out.String())
+	}
+	// the example input: average waits are FCFS 3.33, SJF 2.67, Priority 5.67 and RR 5.00
+	want := map[string]float64{
+		"First-come, first-serve": 10 / 3.0,
+		"Shortest-job-first":      8 / 3.0,
+		"Priority":                17 / 3.0,
+		"Round-robin":             5,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got metrics for %d algorithms, want %d", len(got), len(want))
+	}
+	for _, m := range got {
+		if round2(m.AverageWait) != round2(want[m.Title]) {
+			t.Errorf("%s average wait = %.2f, want %.2f", m.Title, m.AverageWait, want[m.Title])
+		}
+		if m.AverageTurnaround <= m.AverageWait {
+			t.Errorf("%s average turnaround = %.2f, want more than the wait", m.Title, m.AverageTurnaround)
+		}
+	}
+
+	full, err := os.ReadFile(fullPath)
+	if err != nil {
+		t.Fatalf("reading full output: %v", err)
+	}
+	for _, section := range []string{"Gantt schedule", "Schedule table", "Makespan:"} {
+		if !strings.Contains(string(full), section) {
+			t.Errorf("full output missing %q:\n%s", section, full)
+		}
+	}
+}
+
+func Test_FCFSScheduleWithMetrics(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	var out bytes.Buffer
+	got, err := FCFSScheduleWithMetrics(&out, "FCFS", processes)
+	if err != nil {
+		t.Fatalf("FCFSScheduleWithMetrics() error = %v", err)
+	}
+	want := []ProcessMetrics{
+		{PID: 1, Wait: 0, Turnaround: 5, Completion: 5, Response: 0},
+		{PID: 2, Wait: 2, Turnaround: 11, Completion: 14, Response: 2},
+		{PID: 3, Wait: 8, Turnaround: 14, Completion: 20, Response: 8},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FCFSScheduleWithMetrics() = %v, want %v", got, want)
+	}
+	if !strings.Contains(out.String(), "Schedule table") {
+		t.Errorf("output missing the schedule table:\n%s", out.String())
+	}
+
+	bad := append([]Process{}, processes...)
+	bad[2].ProcessID = 1
+	if _, err := FCFSScheduleWithMetrics(io.Discard, "FCFS", bad); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("FCFSScheduleWithMetrics() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// defaultMLFQQuanta are the time quanta of the multilevel feedback queues, top queue first,
+// used unless others are chosen.
+var defaultMLFQQuanta = []int64{2, 4, 8}
+
+// mlfqQuanta returns the time quanta of the multilevel feedback queues to use.
+func (o Options) mlfqQuanta() []int64 {
+	if len(o.MLFQQuanta) == 0 {
+		return defaultMLFQQuanta
+	}
+
+	return o.MLFQQuanta
+}
+
+// MLFQSchedule writes the multilevel feedback queue schedule of the processes to w.
+func MLFQSchedule(w io.Writer, title string, processes []Process) Result {
+	res := mlfq(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// mlfq is multilevel feedback queue scheduling with one round-robin queue per quantum in
+// Options.MLFQQuanta. Arrivals join the top queue, and a process that uses its queue's
+// whole quantum without finishing drops to the queue below, where it waits behind the
+// processes already there; the bottom queue keeps what sinks to it. The highest non-empty
+// queue always runs, so an arrival preempts a process from a lower queue, which rejoins the
+// back of its own queue without being demoted. Every dispatch starts a new Gantt slice, so a
+// process demoted with nothing else ready shows as two slices. A suspended process keeps
+// its place in its queue while the others pass it by, and one suspended while running
+// rejoins the back of its queue without being demoted.
+func mlfq(processes []Process, opts Options) Result {
+	quanta := opts.mlfqQuanta()
+	var (
+		time        int64
+		finished    int
+		used        int64
+		running     = -1
+		last        = -1
+		gantt       = make([]TimeSlice, 0)
+		queues      = make([][]int, len(quanta))
+		level       = make([]int, len(processes))
+		admitted    = make([]bool, len(processes))
+		remaining   = make([]int64, len(processes))
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+	)
+	for i := range processes {
+		remaining[i] = processes[i].cpuTime()
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+
+	// admit adds the processes arrived by time to the top queue, in arrival order
+	order := arrivalOrder(processes)
+	admit := func() {
+		for _, i := range order {
+			if !admitted[i] && processes[i].ArrivalTime <= time {
+				admitted[i] = true
+				queues[0] = append(queues[0], i)
+			}
+		}
+	}
+	// top returns the highest queue holding a process that is not suspended, and that
+	// process's position in it, or -1, -1 if there is none
+	top := func() (int, int) {
+		for l := range queues {
+			for k, i := range queues[l] {
+				if !isSuspended(opts.Suspensions, processes[i].ProcessID, time) {
+					return l, k
+				}
+			}
+		}
+		return -1, -1
+	}
+
+	for finished != len(processes) {
+		admit()
+
+		// a suspended process gives up the CPU
+		if running != -1 && isSuspended(opts.Suspensions, processes[running].ProcessID, time) {
+			queues[level[running]] = append(queues[level[running]], running)
+			running = -1
+		}
+
+		// a process waiting in a higher queue preempts the running one
+		if running != -1 {
+			if l, _ := top(); l != -1 && l < level[running] {
+				queues[level[running]] = append(queues[level[running]], running)
+				running = -1
+			}
+		}
+
+		if running == -1 {
+			l, k := top()
+			if l == -1 {
+				idle := nextEvent(processes, remaining, opts, time)
+				opts.onTicks(time, idle, idlePID, readyPIDs(processes, remaining, opts, time, idlePID))
+				time = idle
+				continue
+			}
+			running = queues[l][k]
+			queues[l] = append(queues[l][:k:k], queues[l][k+1:]...)
+			used = 0
+
+			if opts.Explain != nil {
+				explainDispatch(opts.Explain, time, processes[running].ProcessID,
+					fmt.Sprintf("first ready in queue %d (quantum %d)", l, quanta[l]),
+					readyLabels(processes, remaining, time, func(i int) string {
+						return fmt.Sprintf("P%d:q%d", processes[i].ProcessID, level[i])
+					}))
+			}
+
+			// a powered down CPU has to wake, and switching class costs setup time,
+			// before the process can run
+			dispatched := time
+			time += opts.PowerDown.wakeDelay(gantt, time)
+			if last != -1 {
+				time += opts.Setup.between(processes[last].Class, processes[running].Class)
+			}
+			last = running
+			opts.onTicks(dispatched, time, idlePID, readyPIDs(processes, remaining, opts, dispatched, idlePID))
+			gantt = append(gantt, TimeSlice{PID: processes[running].ProcessID, Start: time, Stop: time})
+		}
+
+		// run the process for one tick
+		pid := processes[running].ProcessID
+		opts.onTicks(time, time+1, pid, readyPIDs(processes, remaining, opts, time, pid))
+		gantt[len(gantt)-1].Stop++
+		remaining[running]--
+		used++
+		time++
+
+		switch {
+		case remaining[running] == 0:
+			finished++
+			completions[running] = time
+			turnArounds[running] = time - processes[running].ArrivalTime
+			waitTimes[running] = turnArounds[running] - processes[running].cpuTime() -
+				suspendedFor(opts.Suspensions, processes[running].ProcessID, processes[running].ArrivalTime, time)
+			running = -1
+		case used == quanta[level[running]]:
+			// arrivals during the quantum queue ahead of the demoted process
+			admit()
+			if level[running] < len(quanta)-1 {
+				level[running]++
+			}
+			queues[level[running]] = append(queues[level[running]], running)
+			running = -1
+		}
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+	}
+}
+
+// parseQuanta parses comma separated time quanta, each at least one tick.
+func parseQuanta(v string) ([]int64, error) {
+	var quanta []int64
+	for _, field := range strings.Split(v, ",") {
+		q, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil || q < 1 {
+			return nil, fmt.Errorf("%w: quantum %q must be a whole number of ticks >= 1", ErrInvalidArgs, field)
+		}
+		quanta = append(quanta, q)
+	}
+
+	return quanta, nil
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_parseQuanta(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		v       string
+		want    []int64
+		wantErr error
+	}{
+		{name: "success", v: "1, 3,9", want: []int64{1, 3, 9}},
+		{name: "zero", v: "2,0", wantErr: ErrInvalidArgs},
+		{name: "not a number", v: "2,long", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseQuanta(tt.v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseQuanta() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseQuanta() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
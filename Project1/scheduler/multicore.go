@@ -0,0 +1,245 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// cpus returns how many CPUs to schedule on.
+func (o Options) cpus() int {
+	if o.CPUs < 1 {
+		return 1
+	}
+
+	return o.CPUs
+}
+
+// FCFSScheduleMulti writes the FCFS schedule of the processes on cpus CPUs to w.
+func FCFSScheduleMulti(w io.Writer, title string, processes []Process, cpus int) Result {
+	res := fcfsMulti(processes, Options{CPUs: cpus})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// SJFScheduleMulti writes the SJF schedule of the processes on cpus CPUs to w.
+func SJFScheduleMulti(w io.Writer, title string, processes []Process, cpus int) Result {
+	res := sjfMulti(processes, Options{CPUs: cpus})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// checkCPUs returns ErrInvalidArgs if o schedules on more than one CPU along with
+// suspensions, setup times or power-down, none of which the multi-CPU schedulers model.
+func (o Options) checkCPUs() error {
+	if o.cpus() == 1 {
+		return nil
+	}
+	unmodelled := ""
+	switch {
+	case len(o.Suspensions) > 0:
+		unmodelled = "suspensions"
+	case o.Setup.Default != 0 || len(o.Setup.Matrix) > 0:
+		unmodelled = "setup times"
+	case o.PowerDown.After > 0:
+		unmodelled = "power-down"
+	}
+	if unmodelled != "" {
+		return fmt.Errorf("%w: %s cannot be scheduled on %d CPUs", ErrInvalidArgs, unmodelled, o.cpus())
+	}
+
+	return nil
+}
+
+// fcfsMulti is FCFS on Options.CPUs CPUs: each CPU that falls idle takes the process that
+// arrived first, breaking ties by PID as FCFS does.
+func fcfsMulti(processes []Process, opts Options) Result {
+	return runMulti(processes, opts, false, func(_ []int64, a, b int) int {
+		if c := compareInt64(processes[a].ArrivalTime, processes[b].ArrivalTime); c != 0 {
+			return c
+		}
+		return compareInt64(processes[a].ProcessID, processes[b].ProcessID)
+	})
+}
+
+// sjfMulti is SJF on Options.CPUs CPUs: every tick the arrived processes with the least
+// remaining time hold the CPUs, preempting any with more.
+func sjfMulti(processes []Process, opts Options) Result {
+	return runMulti(processes, opts, true, func(remaining []int64, a, b int) int {
+		return compareInt64(remaining[a], remaining[b])
+	})
+}
+
+// runMulti runs the processes one tick at a time on Options.CPUs CPUs. Each tick the
+// arrived processes compare ranks first, given each one's remaining time, hold the CPUs: a
+// running process keeps its CPU, unless preemptive and outranked, and the rest take the
+// idle CPUs lowest numbered first. Result.Cores holds each CPU's Gantt chart, and
+// Result.Gantt every slice in order of start. Options.checkCPUs lists what it does not
+// model.
+func runMulti(processes []Process, opts Options, preemptive bool, compare func(remaining []int64, a, b int) int) Result {
+	var (
+		time        int64
+		finished    int
+		remaining   = make([]int64, len(processes))
+		cores       = make([][]TimeSlice, opts.cpus())
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+		// onCPU holds the index of the process on each CPU, or -1 while it is idle
+		onCPU = make([]int, opts.cpus())
+	)
+	for i := range processes {
+		remaining[i] = processes[i].cpuTime()
+	}
+	for c := range onCPU {
+		onCPU[c] = -1
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+	cpuOf := func(i int) int {
+		for c, j := range onCPU {
+			if j == i {
+				return c
+			}
+		}
+		return -1
+	}
+	ready := func(i int) bool {
+		return processes[i].ArrivalTime <= time && remaining[i] > 0
+	}
+	// a running process keeps its CPU against one ranked equal, and Options.TieBreak
+	// settles any other tie
+	rank := func(a, b int) int {
+		if c := compare(remaining, a, b); c != 0 {
+			return c
+		}
+		switch aRuns, bRuns := cpuOf(a) != -1, cpuOf(b) != -1; {
+		case aRuns && !bRuns:
+			return -1
+		case bRuns && !aRuns:
+			return 1
+		}
+		return opts.tieBreak(processes, a, b)
+	}
+
+	for finished != len(processes) {
+		// choose what runs this tick, best ranked first
+		var (
+			chosen = make([]int, 0, len(onCPU))
+			taken  = make([]bool, len(processes))
+		)
+		if !preemptive {
+			for _, i := range onCPU {
+				if i != -1 {
+					chosen = append(chosen, i)
+					taken[i] = true
+				}
+			}
+		}
+		for len(chosen) < len(onCPU) {
+			next := selectNext(processes, func(i int) bool { return !taken[i] && ready(i) }, rank)
+			if next == -1 {
+				break
+			}
+			chosen = append(chosen, next)
+			taken[next] = true
+		}
+		if len(chosen) == 0 {
+			// nothing has arrived, so skip ahead to the next arrival
+			next := int64(-1)
+			for i := range processes {
+				if remaining[i] > 0 && (next == -1 || processes[i].ArrivalTime < next) {
+					next = processes[i].ArrivalTime
+				}
+			}
+			time = next
+			continue
+		}
+
+		// outranked processes give up their CPUs to the newly chosen
+		for c, i := range onCPU {
+			if i != -1 && !taken[i] {
+				onCPU[c] = -1
+			}
+		}
+		for _, next := range chosen {
+			if cpuOf(next) != -1 {
+				continue
+			}
+			core := cpuOf(-1)
+			if opts.Explain != nil {
+				var waiting []string
+				for i := range processes {
+					if ready(i) && cpuOf(i) == -1 {
+						waiting = append(waiting, fmt.Sprintf("P%d:%d", processes[i].ProcessID, remaining[i]))
+					}
+				}
+				explainDispatch(opts.Explain, time, processes[next].ProcessID,
+					fmt.Sprintf("first ranked for idle CPU %d", core), waiting)
+			}
+			onCPU[core] = next
+		}
+
+		for c, i := range onCPU {
+			if i == -1 {
+				continue
+			}
+			cores[c] = runTick(cores[c], processes[i].ProcessID, time)
+			if remaining[i]--; remaining[i] == 0 {
+				finished++
+				onCPU[c] = -1
+				completions[i] = time + 1
+				turnArounds[i] = completions[i] - processes[i].ArrivalTime
+				waitTimes[i] = turnArounds[i] - processes[i].cpuTime()
+			}
+		}
+		time++
+	}
+
+	gantt := make([]TimeSlice, 0, len(processes))
+	for _, slices := range cores {
+		gantt = append(gantt, slices...)
+	}
+	sort.SliceStable(gantt, func(a, b int) bool { return gantt[a].Start < gantt[b].Start })
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Cores:      cores,
+		Throughput: throughput(processes, gantt),
+	}
+}
+
+// outputCoresGantt writes a Gantt chart for each CPU, all starting from the earliest slice
+// so their axes line up.
+func outputCoresGantt(w io.Writer, cores [][]TimeSlice) {
+	var first, last int64 = -1, 0
+	for _, slices := range cores {
+		for _, s := range slices {
+			if first == -1 || s.Start < first {
+				first = s.Start
+			}
+			if s.Stop > last {
+				last = s.Stop
+			}
+		}
+	}
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	for c, slices := range cores {
+		drawn := slices
+		switch {
+		case len(slices) == 0:
+			drawn = []TimeSlice{{PID: idlePID, Start: first, Stop: last}}
+		case slices[0].Start > first:
+			drawn = append([]TimeSlice{{PID: idlePID, Start: first, Stop: slices[0].Start}}, slices...)
+		}
+		_, _ = fmt.Fprintf(w, "CPU %d\n%s", c, ganttString(drawn))
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_runMulti(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 2, BurstDuration: 3},
+	}
+
+	tests := []struct {
+		name      string
+		schedule  scheduleFunc
+		wantCores [][]TimeSlice
+		wantWait  []int64
+	}{
+		{
+			name:     "fcfs",
+			schedule: infallible(FCFS),
+			wantCores: [][]TimeSlice{
+				{{PID: 1, Start: 0, Stop: 6}, {PID: 4, Start: 6, Stop: 9}},
+				{{PID: 2, Start: 0, Stop: 4}, {PID: 3, Start: 4, Stop: 6}},
+			},
+			wantWait: []int64{0, 0, 3, 4},
+		},
+		{
+			// P3 arrives with less to do than P1 has left and takes its CPU, as P4 later
+			// takes it from P1 again
+			name:     "sjf",
+			schedule: infallible(SJF),
+			wantCores: [][]TimeSlice{
+				{{PID: 2, Start: 0, Stop: 4}, {PID: 1, Start: 4, Stop: 9}},
+				{{PID: 1, Start: 0, Stop: 1}, {PID: 3, Start: 1, Stop: 3}, {PID: 4, Start: 3, Stop: 6}},
+			},
+			wantWait: []int64{3, 0, 0, 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			single := mustSchedule(t, tt.schedule, processes, Options{})
+			got := mustSchedule(t, tt.schedule, processes, Options{CPUs: 2})
+			if !reflect.DeepEqual(got.Cores, tt.wantCores) {
+				t.Errorf("Cores = %v, want %v", got.Cores, tt.wantCores)
+			}
+			if !reflect.DeepEqual(got.Wait, tt.wantWait) {
+				t.Errorf("Wait = %v, want %v", got.Wait, tt.wantWait)
+			}
+			span, singleSpan := Makespan(processes, got.Gantt), Makespan(processes, single.Gantt)
+			if span != 9 || singleSpan != 15 {
+				t.Errorf("makespan on 2 CPUs = %d and on 1 = %d, want 9 and 15", span, singleSpan)
+			}
+		})
+	}
+}
+
+func Test_outputCoresGantt(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 3},
+	}
+	var out bytes.Buffer
+	FCFSScheduleMulti(&out, "First-come, first-serve", processes, 3)
+	want := "Gantt schedule\n" +
+		"CPU 0\n| 1  |\n0    4\n" +
+		"CPU 1\n| idle | 2 |\n0      2   5\n" +
+		"CPU 2\n| idle |\n0      5\n\n"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("output missing a Gantt row per CPU:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if !strings.Contains(out.String(), "Makespan: 5 (lower bound 4 on 3 CPUs)") {
+		t.Errorf("output missing the makespan on 3 CPUs:\n%s", out.String())
+	}
+}
+
+func Test_runMulti_dispatchLatency(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+	}
+	for _, schedule := range []func([]Process, Options) Result{FCFS, SJF} {
+		got := schedule(processes, Options{CPUs: 2, DispatchLatency: 3})
+		want := [][]TimeSlice{{{PID: 1, Start: 3, Stop: 5}}, {{PID: 2, Start: 3, Stop: 5}}}
+		if !reflect.DeepEqual(got.Cores, want) {
+			t.Errorf("Cores = %v, want %v", got.Cores, want)
+		}
+		if want := []int64{3, 3}; !reflect.DeepEqual(got.Wait, want) {
+			t.Errorf("Wait = %v, want %v", got.Wait, want)
+		}
+	}
+}
+
+func Test_checkCPUs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "one CPU with setup", args: []string{"-setup-time=1"}},
+		{name: "setup", args: []string{"-cpus=2", "-setup-time=1"}, wantErr: ErrInvalidArgs},
+		{name: "setup matrix", args: []string{"-cpus=2", "-setup-matrix=A>B=1"}, wantErr: ErrInvalidArgs},
+		{name: "power-down", args: []string{"-cpus=2", "-power-down-after=3"}, wantErr: ErrInvalidArgs},
+		{name: "suspensions", args: []string{"-cpus=2", "-suspensions=testdata/suspensions.csv"}, wantErr: ErrInvalidArgs},
+		{name: "dispatch latency", args: []string{"-cpus=2", "-dispatch-latency=2"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			args := append(append([]string{"scheduler", "-algorithm=fcfs"}, tt.args...), "../example_processes.csv")
+			if err := Run(args, io.Discard); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package scheduler
+
+// runToCompletion runs a non-preemptive schedule: at each dispatch pick chooses which
+// arrived process runs to completion, given the time, the CPU time each process has left
+// (0 once finished or while suspended) and the index of the last one run, or -1 if it is
+// the first. pick returns -1 when nothing is ready, and the CPU idles until the next
+// arrival or resumption. Like FCFS, the CPU also idles while the running process is
+// suspended, since nothing else may take it over.
+func runToCompletion(processes []Process, opts Options, pick func(time int64, remaining []int64, last int) int) Result {
+	var (
+		time        int64
+		finished    int
+		last        = -1
+		gantt       = make([]TimeSlice, 0)
+		remaining   = make([]int64, len(processes))
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+	)
+	for i := range processes {
+		// a process with no CPU time to use completes as it arrives
+		remaining[i] = processes[i].cpuTime()
+		if remaining[i] == 0 {
+			finished++
+			completions[i] = processes[i].ArrivalTime
+		}
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+
+	for ; finished < len(processes); finished++ {
+		next := pick(time, unsuspended(processes, remaining, opts, time), last)
+		for next == -1 {
+			time = nextEvent(processes, remaining, opts, time)
+			next = pick(time, unsuspended(processes, remaining, opts, time), last)
+		}
+
+		// a powered down CPU has to wake, and switching class costs setup time, before
+		// the process can run
+		time += opts.PowerDown.wakeDelay(gantt, time)
+		if last != -1 {
+			time += opts.Setup.between(processes[last].Class, processes[next].Class)
+		}
+		last = next
+
+		// run the burst, leaving the CPU idle while the process is suspended
+		pid := processes[next].ProcessID
+		for time = resumeTime(opts.Suspensions, pid, time); remaining[next] > 0; {
+			stop := time + remaining[next]
+			if suspend := nextSuspension(opts.Suspensions, pid, time); suspend < stop {
+				stop = suspend
+			}
+			gantt = append(gantt, TimeSlice{PID: pid, Start: time, Stop: stop})
+			remaining[next] -= stop - time
+			time = stop
+			if remaining[next] > 0 {
+				time = resumeTime(opts.Suspensions, pid, time)
+			}
+		}
+		completions[next] = time
+		turnArounds[next] = time - processes[next].ArrivalTime
+		waitTimes[next] = turnArounds[next] - processes[next].cpuTime() -
+			suspendedFor(opts.Suspensions, pid, processes[next].ArrivalTime, time)
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+	}
+}
+
+// unsuspended returns the CPU time each process has left, with the processes suspended at
+// time shown as having none, so that a pick passes them over.
+func unsuspended(processes []Process, remaining []int64, opts Options, time int64) []int64 {
+	if len(opts.Suspensions) == 0 {
+		return remaining
+	}
+	ready := make([]int64, len(remaining))
+	for i := range remaining {
+		if !isSuspended(opts.Suspensions, processes[i].ProcessID, time) {
+			ready[i] = remaining[i]
+		}
+	}
+
+	return ready
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// idlePID marks a time unit in which no process held the CPU.
+const idlePID = -1
+
+// occupancy returns the PID on the CPU during each time unit from 0 until the last slice
+// stops, or idlePID where the CPU sat idle.
+func occupancy(gantt []TimeSlice) []int64 {
+	var end int64
+	for _, slice := range gantt {
+		if slice.Stop > end {
+			end = slice.Stop
+		}
+	}
+	pids := make([]int64, end)
+	for t := range pids {
+		pids[t] = idlePID
+	}
+	for _, slice := range gantt {
+		for t := slice.Start; t < slice.Stop; t++ {
+			pids[t] = slice.PID
+		}
+	}
+
+	return pids
+}
+
+// occupancies returns the occupancy of each CPU the result was scheduled on.
+func (r Result) occupancies() [][]int64 {
+	if len(r.Cores) < 2 {
+		return [][]int64{occupancy(r.Gantt)}
+	}
+	cpus := make([][]int64, len(r.Cores))
+	for c, slices := range r.Cores {
+		cpus[c] = occupancy(slices)
+	}
+
+	return cpus
+}
+
+// writeOccupancyCSV writes a row per time unit giving the PID each result had on the CPU,
+// after a header naming the results, with a column per CPU for a result scheduled on
+// several. Results that finish early are idle for the rest.
+func writeOccupancyCSV(w io.Writer, results []Result) error {
+	var (
+		header  = []string{"time"}
+		columns [][]int64
+		rows    int
+	)
+	for _, res := range results {
+		cpus := res.occupancies()
+		for c, pids := range cpus {
+			if len(cpus) > 1 {
+				header = append(header, fmt.Sprintf("%s CPU %d", res.Title, c))
+			} else {
+				header = append(header, res.Title)
+			}
+			columns = append(columns, pids)
+			if len(pids) > rows {
+				rows = len(pids)
+			}
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(header)
+	for t := 0; t < rows; t++ {
+		row := []string{strconv.Itoa(t)}
+		for _, pids := range columns {
+			pid := int64(idlePID)
+			if t < len(pids) {
+				pid = pids[t]
+			}
+			row = append(row, strconv.FormatInt(pid, 10))
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writeOccupancyFile writes the occupancy CSV for results to the named file.
+func writeOccupancyFile(name string, results []Result) error {
+	var b bytes.Buffer
+	if err := writeOccupancyCSV(&b, results); err != nil {
+		return fmt.Errorf("%w: encoding occupancy", err)
+	}
+	if err := os.WriteFile(name, b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("%v: error writing occupancy", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_writeOccupancyCSV(t *testing.T) {
+	t.Parallel()
+	// P2 arrives after P1 finishes, leaving the CPU idle from 2 to 4
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 4, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 1},
+	}
+	res := FCFS(processes, Options{})
+	res.Title = "First-come, first-serve"
+	wantPIDs := []int64{1, 1, -1, -1, 2, 2, 2, 3}
+
+	var out bytes.Buffer
+	if err := writeOccupancyCSV(&out, []Result{res}); err != nil {
+		t.Fatalf("writeOccupancyCSV() error = %v", err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("reading occupancy CSV: %v", err)
+	}
+
+	if want := []string{"time", res.Title}; !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("header = %v, want %v", rows[0], want)
+	}
+	if got, want := int64(len(rows)-1), Makespan(processes, res.Gantt); got != want {
+		t.Fatalf("got %d rows, want makespan %d", got, want)
+	}
+	for i, row := range rows[1:] {
+		want := []string{strconv.Itoa(i), strconv.FormatInt(wantPIDs[i], 10)}
+		if !reflect.DeepEqual(row, want) {
+			t.Errorf("row %d = %v, want %v", i, row, want)
+		}
+	}
+}
+
+func Test_writeOccupancyCSV_cpus(t *testing.T) {
+	t.Parallel()
+	// P1 holds CPU 0 throughout, while CPU 1 idles between P2 and P3
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+	}
+	res := FCFS(processes, Options{CPUs: 2})
+	res.Title = "FCFS"
+
+	var out bytes.Buffer
+	if err := writeOccupancyCSV(&out, []Result{res}); err != nil {
+		t.Fatalf("writeOccupancyCSV() error = %v", err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("reading occupancy CSV: %v", err)
+	}
+	want := [][]string{
+		{"time", "FCFS CPU 0", "FCFS CPU 1"},
+		{"0", "1", "2"},
+		{"1", "1", "-1"},
+		{"2", "1", "3"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("occupancy CSV = %v, want %v", rows, want)
+	}
+}
@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// OrderPreservation returns the fraction of processes that finish in the same place in
+// the completion order as under FCFS, so 1 when the schedule reorders no outcomes.
+func (r Result) OrderPreservation() float64 {
+	if len(r.Processes) == 0 {
+		return 1
+	}
+	want := completionRanks(FCFS(r.Processes, Options{}).Completion)
+	got := completionRanks(r.Completion)
+	var kept int
+	for i := range got {
+		if got[i] == want[i] {
+			kept++
+		}
+	}
+
+	return float64(kept) / float64(len(got))
+}
+
+// completionRanks returns where each process falls in the order of completion, with
+// processes completing together ranked in input order.
+func completionRanks(completions []int64) []int {
+	order := make([]int, len(completions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return completions[order[a]] < completions[order[b]] })
+	ranks := make([]int, len(completions))
+	for rank, i := range order {
+		ranks[i] = rank
+	}
+
+	return ranks
+}
+
+// outputOrderPreservation writes the fraction of processes completing in FCFS order.
+func outputOrderPreservation(w io.Writer, res Result) {
+	_, _ = fmt.Fprintf(w, "Order preservation: %.2f\n", res.OrderPreservation())
+}
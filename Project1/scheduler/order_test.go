@@ -0,0 +1,31 @@
+package scheduler
+
+import "testing"
+
+func Test_orderPreservation(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 1},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 2},
+	}
+
+	if got := FCFS(processes, Options{}).OrderPreservation(); got != 1 {
+		t.Errorf("FCFS order preservation = %.2f, want 1", got)
+	}
+	// the short last arrival finishes first under SJF
+	if got := SJF(processes, Options{}).OrderPreservation(); got >= 1 {
+		t.Errorf("SJF order preservation = %.2f, want less than 1", got)
+	}
+}
+
+func Test_completionRanks(t *testing.T) {
+	t.Parallel()
+	got := completionRanks([]int64{9, 4, 9, 1})
+	want := []int{2, 1, 3, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("completionRanks() = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+// PowerDown models a CPU that switches itself off after sitting idle for a while and
+// takes time to wake up again when a process needs it.
+type PowerDown struct {
+	// After is how long the CPU idles before powering down; 0 keeps it on.
+	After int64 `json:"after,omitempty"`
+	// Wake is the delay before a process can start on a powered down CPU.
+	Wake int64 `json:"wake,omitempty"`
+}
+
+// wakeDelay returns how long a process dispatched at time waits for the CPU to wake,
+// given the slices run so far. The CPU is off if it has been idle for at least After
+// since the last slice stopped, or since time 0 if nothing has run yet.
+func (p PowerDown) wakeDelay(gantt []TimeSlice, time int64) int64 {
+	if p.After <= 0 {
+		return 0
+	}
+	var idleSince int64
+	if len(gantt) > 0 {
+		idleSince = gantt[len(gantt)-1].Stop
+	}
+	if time-idleSince >= p.After {
+		return p.Wake
+	}
+
+	return 0
+}
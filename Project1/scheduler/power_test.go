@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"testing"
+)
+
+func Test_powerDown(t *testing.T) {
+	t.Parallel()
+	// the CPU is idle from 2 until P2 arrives at 10
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 10, BurstDuration: 3, Priority: 1},
+	}
+	all := append([]algorithm{{"SJF event-driven", infallible(sjfEventDriven)}}, schedulers...)
+
+	tests := []struct {
+		name      string
+		powerDown PowerDown
+		wantStart int64
+	}{
+		{name: "powered down", powerDown: PowerDown{After: 3, Wake: 2}, wantStart: 12},
+		{name: "idle exactly long enough", powerDown: PowerDown{After: 8, Wake: 2}, wantStart: 12},
+		{name: "not idle long enough", powerDown: PowerDown{After: 9, Wake: 2}, wantStart: 10},
+		{name: "never powers down", powerDown: PowerDown{Wake: 2}, wantStart: 10},
+	}
+	for _, tt := range tests {
+		for _, s := range all {
+			tt, s := tt, s
+			t.Run(tt.name+"/"+s.title, func(t *testing.T) {
+				t.Parallel()
+				got := mustSchedule(t, s.schedule, processes, Options{PowerDown: tt.powerDown})
+				if start := got.Gantt[len(got.Gantt)-1].Start; start != tt.wantStart {
+					t.Errorf("P2 started at %d, want %d", start, tt.wantStart)
+				}
+				if got.Gantt[0].Start != 0 {
+					t.Errorf("P1 started at %d, want 0", got.Gantt[0].Start)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// preemptiveAlgorithms lists the preemptive schedulers that have a non-preemptive
+// counterpart, for the preemptive vs non-preemptive report.
+var preemptiveAlgorithms = []algorithm{
+	{"Shortest-job-first", infallible(SJF)},
+	{"Priority", infallible(SJFPriority)},
+}
+
+// nonPreemptive returns schedule with preemption switched off: the running process keeps
+// the CPU until it finishes or is suspended.
+func nonPreemptive(schedule scheduleFunc) scheduleFunc {
+	return func(processes []Process, opts Options) (Result, error) {
+		opts.PreemptGranularity = math.MaxInt64
+		return schedule(processes, opts)
+	}
+}
+
+// preemptionPairs runs each preemptive algorithm and its non-preemptive counterpart,
+// returning the results in pairs, preemptive first.
+func preemptionPairs(processes []Process, opts Options) [][2]Result {
+	pairs := make([][2]Result, 0, len(preemptiveAlgorithms))
+	for _, a := range preemptiveAlgorithms {
+		results := runAlgorithms([]algorithm{
+			a,
+			{a.title + " (non-preemptive)", nonPreemptive(a.schedule)},
+		}, processes, opts)
+		if len(results) == 2 {
+			pairs = append(pairs, [2]Result{results[0], results[1]})
+		}
+	}
+
+	return pairs
+}
+
+// outputPreemptionPairs writes a table per pair comparing the preemptive algorithm with
+// its non-preemptive counterpart on every comparison metric. Each delta is the
+// preemptive value minus the non-preemptive one.
+func outputPreemptionPairs(w io.Writer, processes []Process, opts Options) {
+	_, _ = fmt.Fprintln(w, "Preemptive vs non-preemptive")
+	for _, pair := range preemptionPairs(processes, opts) {
+		table := tablewriter.NewWriter(w)
+		table.SetAutoWrapText(false)
+		table.SetHeader([]string{"Metric", pair[0].Title, pair[1].Title, "Delta"})
+		for _, group := range comparisonGroups {
+			for _, m := range group.metrics {
+				delta := fmt.Sprintf("%+.2f", m.value(pair[0])-m.value(pair[1]))
+				if m.count {
+					delta = fmt.Sprintf("%+.0f", m.value(pair[0])-m.value(pair[1]))
+				}
+				table.Append([]string{m.name, m.format(pair[0]), m.format(pair[1]), delta})
+			}
+		}
+		table.Render()
+	}
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_outputPreemptionPairs(t *testing.T) {
+	t.Parallel()
+	// the example input: without preemption P2 runs straight after P1 under both SJF and
+	// priority, as in FCFS, for an average turnaround of 10.00
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+	}
+	var w bytes.Buffer
+	outputPreemptionPairs(&w, processes, Options{})
+
+	tests := []struct {
+		preemptive, nonPreemptive string
+		turnaround                string
+	}{
+		{
+			preemptive:    "SHORTEST-JOB-FIRST",
+			nonPreemptive: "SHORTEST-JOB-FIRST (NON-PREEMPTIVE)",
+			turnaround:    "| Average turnaround |               9.33 |                               10.00 | -0.67 |",
+		},
+		{
+			preemptive:    "PRIORITY",
+			nonPreemptive: "PRIORITY (NON-PREEMPTIVE)",
+			turnaround:    "| Average turnaround |    12.33 |                     10.00 | +2.33 |",
+		},
+	}
+	tables := strings.Split(w.String(), "|       METRIC")[1:]
+	if len(tables) != len(tests) {
+		t.Fatalf("got %d pairs, want %d:\n%s", len(tables), len(tests), w.String())
+	}
+	for i, tt := range tests {
+		header := strings.SplitN(tables[i], "\n", 2)[0]
+		if !strings.Contains(header, "| "+tt.preemptive+" |") || !strings.Contains(header, "| "+tt.nonPreemptive+" |") {
+			t.Errorf("pair %d header = %q, want %s and %s", i, header, tt.preemptive, tt.nonPreemptive)
+		}
+		if !strings.Contains(tables[i], tt.turnaround) {
+			t.Errorf("pair %d missing turnaround row %q:\n%s", i, tt.turnaround, tables[i])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import "fmt"
+
+// runPreemptive schedules the processes a tick at a time, asking pick at every preemption
+// point which process should have the CPU, given the time, each process's remaining CPU
+// time and the running process (-1 if none). pick returns -1 if nothing is ready. With
+// opts.Explain set, each dispatch is explained as reason followed by the chosen process's
+// key, listing the key of every process ready.
+func runPreemptive(processes []Process, opts Options, reason string, key func(i int, remaining []int64) int64,
+	pick func(time int64, remaining []int64, running int) int,
+) Result {
+	var (
+		time        int64
+		finished    int
+		running     = -1
+		last        = -1
+		gantt       = make([]TimeSlice, 0)
+		remaining   = make([]int64, len(processes))
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+	)
+	for i := range processes {
+		remaining[i] = processes[i].cpuTime()
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+
+	for finished != len(processes) {
+		// a suspended process gives up the CPU
+		if running != -1 && isSuspended(opts.Suspensions, processes[running].ProcessID, time) {
+			running = -1
+		}
+		next := running
+		if next == -1 || opts.preemptionPoint(time) {
+			next = pick(time, remaining, running)
+		}
+
+		// nothing ready: skip ahead to the next arrival or resumption
+		if next == -1 {
+			idle := nextEvent(processes, remaining, opts, time)
+			opts.onTicks(time, idle, idlePID, readyPIDs(processes, remaining, opts, time, idlePID))
+			time = idle
+			continue
+		}
+		running = next
+
+		if opts.Explain != nil && isDispatch(gantt, processes[running].ProcessID, time) {
+			explainDispatch(opts.Explain, time, processes[running].ProcessID,
+				fmt.Sprintf("%s (%d)", reason, key(running, remaining)),
+				readyLabels(processes, remaining, time, func(i int) string {
+					return fmt.Sprintf("P%d:%d", processes[i].ProcessID, key(i, remaining))
+				}))
+		}
+
+		// a powered down CPU has to wake, and switching class costs setup time,
+		// before the process can run
+		dispatched := time
+		time += opts.PowerDown.wakeDelay(gantt, time)
+		if last != -1 {
+			time += opts.Setup.between(processes[last].Class, processes[running].Class)
+		}
+		last = running
+		opts.onTicks(dispatched, time, idlePID, readyPIDs(processes, remaining, opts, dispatched, idlePID))
+
+		pid := processes[running].ProcessID
+		opts.onTicks(time, time+1, pid, readyPIDs(processes, remaining, opts, time, pid))
+		gantt = runTick(gantt, pid, time)
+		remaining[running]--
+		time++
+
+		if remaining[running] == 0 {
+			finished++
+			completions[running] = time
+			turnArounds[running] = time - processes[running].ArrivalTime
+			waitTimes[running] = turnArounds[running] - processes[running].cpuTime() -
+				suspendedFor(opts.Suspensions, processes[running].ProcessID, processes[running].ArrivalTime, time)
+			running = -1
+		}
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+	}
+}
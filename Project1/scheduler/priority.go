@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// PriorityChange sets a process's priority from Time onwards.
+type PriorityChange struct {
+	PID      int64 `json:"pid"`
+	Time     int64 `json:"time"`
+	Priority int64 `json:"priority"`
+}
+
+// priorityAt returns the priority of p at time, after applying every change that has
+// taken effect by then. When several changes share a time the last one listed wins.
+func priorityAt(p Process, changes []PriorityChange, time int64) int64 {
+	priority, since := p.Priority, int64(-1)
+	for _, c := range changes {
+		if c.PID == p.ProcessID && c.Time <= time && c.Time >= since {
+			priority, since = c.Priority, c.Time
+		}
+	}
+
+	return priority
+}
+
+// loadPriorityChanges reads priority changes from CSV rows of pid, time, priority.
+func loadPriorityChanges(r io.Reader) ([]PriorityChange, error) {
+	rows, err := readIntRows(r, "priority change", "pid,time,priority")
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]PriorityChange, len(rows))
+	for i, row := range rows {
+		changes[i] = PriorityChange{PID: row[0], Time: row[1], Priority: row[2]}
+	}
+
+	return changes, nil
+}
+
+// openPriorityChanges loads the priority changes in the named file.
+func openPriorityChanges(name string) ([]PriorityChange, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening priority changes file", err)
+	}
+	defer f.Close()
+
+	return loadPriorityChanges(f)
+}
+
+// agingBoost returns how far aging has raised the priority of a process that has waited
+// for waited ticks.
+func (o Options) agingBoost(waited int64) int64 {
+	if o.Aging <= 0 || waited <= 0 {
+		return 0
+	}
+
+	return waited / o.Aging
+}
@@ -0,0 +1,236 @@
+package scheduler
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_sjfPriority_priorityChanges(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 8, Priority: 3},
+	}
+
+	tests := []struct {
+		name    string
+		changes []PriorityChange
+		want    []TimeSlice
+	}{
+		{
+			name: "no changes",
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 6},
+				{PID: 2, Start: 6, Stop: 14},
+			},
+		},
+		{
+			name:    "improved priority preempts at the change",
+			changes: []PriorityChange{{PID: 2, Time: 3, Priority: 1}},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 3, Stop: 11},
+				{PID: 1, Start: 11, Stop: 14},
+			},
+		},
+		{
+			name:    "change before arrival applies on arrival",
+			changes: []PriorityChange{{PID: 2, Time: 0, Priority: 1}},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 9},
+				{PID: 1, Start: 9, Stop: 14},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := SJFPriority(processes, Options{PriorityChanges: tt.changes})
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sjfPriority_preemption(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+	}{
+		{
+			// P2 arrives in the middle of P1's burst with a better priority
+			name: "higher priority arrival preempts",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 3},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 5},
+				{PID: 1, Start: 5, Stop: 9},
+			},
+		},
+		{
+			// priority decides first, so a shorter job with a worse priority waits
+			name: "shorter arrival with a worse priority does not",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1, Priority: 2},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 6},
+				{PID: 2, Start: 6, Stop: 7},
+			},
+		},
+		{
+			// on equal priority the remaining time breaks the tie
+			name: "equal priority with less remaining preempts",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 4, BurstDuration: 1, Priority: 1},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 4},
+				{PID: 3, Start: 4, Stop: 5},
+				{PID: 1, Start: 5, Stop: 9},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := PriorityPreemptiveSchedule(io.Discard, "Priority", tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.want)
+			}
+			// the old name schedules the same
+			if old := SJFPrioritySchedule(io.Discard, "Priority", tt.processes); !reflect.DeepEqual(old, got) {
+				t.Errorf("SJFPrioritySchedule() = %+v, want %+v", old, got)
+			}
+		})
+	}
+}
+
+func Test_sjfPriority_lateFirstProcess(t *testing.T) {
+	t.Parallel()
+	// P1 is listed first with the best priority but arrives last; at t=0 only P2 and P3
+	// have arrived, so P1 must not be the baseline the others are compared against
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 5, BurstDuration: 2, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Priority: 3},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+	}
+	want := []TimeSlice{
+		{PID: 3, Start: 0, Stop: 3},
+		{PID: 2, Start: 3, Stop: 5},
+		{PID: 1, Start: 5, Stop: 7},
+		{PID: 2, Start: 7, Stop: 9},
+	}
+	got := SJFPriority(processes, Options{})
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", got.Gantt, want)
+	}
+	if wantWait := []int64{0, 5, 0}; !reflect.DeepEqual(got.Wait, wantWait) {
+		t.Errorf("Wait = %v, want %v", got.Wait, wantWait)
+	}
+}
+
+func Test_sjfPriority_aging(t *testing.T) {
+	t.Parallel()
+	// a stream of priority 1 jobs keeps the CPU busy, so P1 runs last unless aging lifts it
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 4, Priority: 1},
+		{ProcessID: 4, ArrivalTime: 8, BurstDuration: 4, Priority: 1},
+		{ProcessID: 5, ArrivalTime: 12, BurstDuration: 4, Priority: 1},
+	}
+
+	tests := []struct {
+		name  string
+		aging int64
+		want  []TimeSlice
+	}{
+		{
+			name: "no aging",
+			want: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 4},
+				{PID: 3, Start: 4, Stop: 8},
+				{PID: 4, Start: 8, Stop: 12},
+				{PID: 5, Start: 12, Stop: 16},
+				{PID: 1, Start: 16, Stop: 19},
+			},
+		},
+		{
+			// after 12 ticks of waiting P1 has aged from 5 to 1, and beats P5 on remaining time
+			name:  "aged every 3 ticks",
+			aging: 3,
+			want: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 4},
+				{PID: 3, Start: 4, Stop: 8},
+				{PID: 4, Start: 8, Stop: 12},
+				{PID: 1, Start: 12, Stop: 15},
+				{PID: 5, Start: 15, Stop: 19},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := SJFPriority(processes, Options{Aging: tt.aging})
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.want)
+			}
+			if got.Processes[0].Priority != 5 {
+				t.Errorf("Priority = %d, want the static priority 5", got.Processes[0].Priority)
+			}
+		})
+	}
+}
+
+func Test_loadPriorityChanges(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []PriorityChange
+		wantErr error
+	}{
+		{
+			name:  "success",
+			input: "2,5,1\n1, 7, 3\n",
+			want: []PriorityChange{
+				{PID: 2, Time: 5, Priority: 1},
+				{PID: 1, Time: 7, Priority: 3},
+			},
+		},
+		{name: "missing field", input: "2,5\n", wantErr: ErrInvalidArgs},
+		{name: "not a number", input: "2,soon,1\n", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadPriorityChanges(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadPriorityChanges() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadPriorityChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// cpuTime returns the CPU time the process gets: its burst, cut short at its quota.
+func (p Process) cpuTime() int64 {
+	if p.Quota > 0 && p.Quota < p.BurstDuration {
+		return p.Quota
+	}
+
+	return p.BurstDuration
+}
+
+// QuotaExceeded reports whether the process at index i was terminated on using up its
+// quota before finishing its burst.
+func (r Result) QuotaExceeded(i int) bool {
+	return r.Processes[i].cpuTime() < r.Processes[i].BurstDuration
+}
+
+// outputQuotaExceeded flags each process terminated at its quota.
+func outputQuotaExceeded(w io.Writer, res Result) {
+	for i, p := range res.Processes {
+		if res.QuotaExceeded(i) {
+			_, _ = fmt.Fprintf(w, "P%d quota-exceeded: terminated at %d after %d of its %d burst\n",
+				p.ProcessID, res.Completion[i], p.Quota, p.BurstDuration)
+		}
+	}
+}
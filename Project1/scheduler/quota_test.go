@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_quota(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 10, ArrivalTime: 0, Priority: 1, Quota: 3},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 2, Quota: 5},
+	}
+	all := append([]algorithm{{"SJF event-driven", infallible(sjfEventDriven)}, {"WFQ", infallible(wfq)}}, schedulers...)
+
+	for _, a := range all {
+		a := a
+		t.Run(a.title, func(t *testing.T) {
+			t.Parallel()
+			res := mustSchedule(t, a.schedule, processes, Options{})
+			used := make(map[int64]int64)
+			for _, slice := range res.Gantt {
+				used[slice.PID] += slice.Stop - slice.Start
+			}
+			if used[1] != 3 || used[2] != 2 {
+				t.Errorf("CPU used = %v, want P1 cut off at its quota of 3 and P2 to finish its burst of 2", used)
+			}
+			// P1 is terminated as its third unit ends
+			for _, slice := range res.Gantt {
+				if slice.PID == 1 && slice.Stop > res.Completion[0] {
+					t.Errorf("P1 ran until %d after terminating at %d", slice.Stop, res.Completion[0])
+				}
+			}
+			if !res.QuotaExceeded(0) || res.QuotaExceeded(1) {
+				t.Errorf("QuotaExceeded = %v, %v, want true, false", res.QuotaExceeded(0), res.QuotaExceeded(1))
+			}
+			if want := res.Turnaround[0] - 3; res.Wait[0] != want {
+				t.Errorf("P1 wait = %d, want %d", res.Wait[0], want)
+			}
+		})
+	}
+
+	t.Run("flagged in the output", func(t *testing.T) {
+		t.Parallel()
+		var w bytes.Buffer
+		FCFSSchedule(&w, "First-come, first-serve", processes)
+		if want := "P1 quota-exceeded: terminated at 3 after 3 of its 10 burst\n"; !strings.Contains(w.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, w.String())
+		}
+		if strings.Contains(w.String(), "P2 quota-exceeded") {
+			t.Errorf("P2 finished within its quota but was flagged:\n%s", w.String())
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrReferenceMismatch is returned when the output differs from a reference file.
+var ErrReferenceMismatch = errors.New("output does not match the reference")
+
+// compareAgainst diffs the output for results against the named reference file, writing
+// each differing line to w. A reference holding a single schedule is compared with the
+// output of the algorithm named in its title, any other with the output of the whole run.
+func compareAgainst(w io.Writer, name string, results []Result, cfg config) error {
+	want, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("%v: error reading reference", err)
+	}
+
+	var got bytes.Buffer
+	if title, ok := referenceTitle(string(want)); ok {
+		for _, res := range results {
+			if strings.EqualFold(res.Title, title) {
+				outputResult(&got, title, res, cfg)
+				break
+			}
+		}
+	}
+	if got.Len() == 0 {
+		outputAll(&got, results, cfg)
+	}
+
+	diffs := diffLines(string(want), got.String())
+	for _, d := range diffs {
+		_, _ = fmt.Fprint(w, d)
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("%w: %d lines differ from %s", ErrReferenceMismatch, len(diffs), name)
+	}
+	_, _ = fmt.Fprintln(w, "output matches", name)
+
+	return nil
+}
+
+// referenceTitle returns the title from the banner outputTitle writes at the top of a
+// schedule, if the reference holds exactly one schedule.
+func referenceTitle(ref string) (string, bool) {
+	if strings.Count(ref, "Gantt schedule\n") != 1 {
+		return "", false
+	}
+	lines := strings.SplitN(ref, "\n", 3)
+	if len(lines) < 3 || lines[0] == "" || strings.Trim(lines[0], "-") != "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(lines[1]), true
+}
+
+// diffLines compares want and got line by line, returning a description of each line
+// that differs. A line missing from either side is shown as empty.
+func diffLines(want, got string) []string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	n := len(wantLines)
+	if len(gotLines) > n {
+		n = len(gotLines)
+	}
+
+	var diffs []string
+	for i := 0; i < n; i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			diffs = append(diffs, fmt.Sprintf("line %d:\n- %s\n+ %s\n", i+1, w, g))
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_run_compareAgainst(t *testing.T) {
+	t.Parallel()
+	single, err := os.ReadFile("fcfs_test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	whole, err := os.ReadFile(filepath.Join("testdata", "fixtures", "example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		reference string
+		wantErr   error
+		wantDiff  string
+	}{
+		{name: "matching single algorithm", reference: string(single)},
+		{name: "matching whole run", reference: string(whole)},
+		{
+			name:      "mismatching single algorithm",
+			reference: strings.Replace(string(single), "|  2 |        1 |     9 |", "|  2 |        1 |     8 |", 1),
+			wantErr:   ErrReferenceMismatch,
+			wantDiff:  "line 13:\n- |  2 |        1 |     8 |",
+		},
+		{
+			name:      "reference with a missing line",
+			reference: strings.TrimSuffix(string(whole), "Makespan: 20\n"),
+			wantErr:   ErrReferenceMismatch,
+			wantDiff:  "\n+ Makespan: 20\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reference := filepath.Join(t.TempDir(), "reference.txt")
+			if err := os.WriteFile(reference, []byte(tt.reference), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			err := Run([]string{"binary_name", "-compare-against", reference, "../example_processes.csv"}, &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("run() error = %v, want %v\n%s", err, tt.wantErr, out.String())
+			}
+			if !strings.Contains(out.String(), tt.wantDiff) {
+				t.Errorf("run() output = %q, want it to contain %q", out.String(), tt.wantDiff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type (
+	// Report is the structured form of one algorithm's results written by -format=json.
+	Report struct {
+		Algorithm         string      `json:"algorithm"`
+		Processes         []ReportRow `json:"processes"`
+		AverageWait       float64     `json:"average_wait"`
+		AverageTurnaround float64     `json:"average_turnaround"`
+		Throughput        float64     `json:"throughput"`
+		Gantt             []TimeSlice `json:"gantt"`
+	}
+	// ReportRow holds one process's row of the schedule table.
+	ReportRow struct {
+		PID        int64 `json:"pid"`
+		Arrival    int64 `json:"arrival"`
+		Burst      int64 `json:"burst"`
+		Priority   int64 `json:"priority"`
+		Wait       int64 `json:"wait"`
+		Turnaround int64 `json:"turnaround"`
+		Completion int64 `json:"completion"`
+		Response   int64 `json:"response"`
+	}
+)
+
+// outputFormats lists the formats accepted by -format.
+var outputFormats = map[string]bool{"text": true, "markdown": true, "json": true}
+
+// reports returns the structured report of each result.
+func reports(results []Result) []Report {
+	out := make([]Report, len(results))
+	for i, res := range results {
+		response := res.ResponseTimes()
+		rows := make([]ReportRow, len(res.Processes))
+		for j, p := range res.Processes {
+			rows[j] = ReportRow{
+				PID:        p.ProcessID,
+				Arrival:    p.ArrivalTime,
+				Burst:      p.BurstDuration,
+				Priority:   p.Priority,
+				Wait:       res.Wait[j],
+				Turnaround: res.Turnaround[j],
+				Completion: res.Completion[j],
+				Response:   response[j],
+			}
+		}
+		out[i] = Report{
+			Algorithm:         res.Title,
+			Processes:         rows,
+			AverageWait:       res.AverageWait(),
+			AverageTurnaround: res.AverageTurnaround(),
+			Throughput:        res.Throughput,
+			Gantt:             res.Gantt,
+		}
+	}
+
+	return out
+}
+
+// writeReports writes the report of every result to w as indented JSON.
+func writeReports(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(reports(results)); err != nil {
+		return fmt.Errorf("%w: encoding reports", err)
+	}
+
+	return nil
+}
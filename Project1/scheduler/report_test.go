@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_run_formatJSON(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := Run([]string{"binary_name", "-format=json", "../example_processes.csv"}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	var got []Report
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON reports: %v\n%s", err, out.String())
+	}
+	if len(got) != len(schedulers) {
+		t.Fatalf("got %d reports, want %d", len(got), len(schedulers))
+	}
+
+	first := got[0]
+	if first.Algorithm != "First-come, first-serve" {
+		t.Errorf("first algorithm = %q, want First-come, first-serve", first.Algorithm)
+	}
+	// P1 waits 0, P2 2 and P3 8
+	if want := 10.0 / 3; math.Abs(first.AverageWait-want) > 1e-9 {
+		t.Errorf("FCFS average wait = %v, want %v", first.AverageWait, want)
+	}
+	wantRow := ReportRow{PID: 2, Arrival: 3, Burst: 9, Priority: 1, Wait: 2, Turnaround: 11, Completion: 14, Response: 2}
+	if !reflect.DeepEqual(first.Processes[1], wantRow) {
+		t.Errorf("FCFS P2 = %+v, want %+v", first.Processes[1], wantRow)
+	}
+	wantGantt := []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}, {PID: 3, Start: 14, Stop: 20}}
+	if !reflect.DeepEqual(first.Gantt, wantGantt) {
+		t.Errorf("FCFS Gantt = %v, want %v", first.Gantt, wantGantt)
+	}
+
+	if err := Run([]string{"binary_name", "-format=yaml", "../example_processes.csv"}, io.Discard); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("run(-format=yaml) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+import "io"
+
+// RateMonotonicSchedule writes the rate monotonic schedule of the processes to w.
+func RateMonotonicSchedule(w io.Writer, title string, processes []Process) Result {
+	res := rateMonotonic(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// rateMonotonic is rate monotonic scheduling of periodic tasks: each process with a Period
+// is released at its arrival and every period after, up to the end of the hyperperiod (the
+// least common multiple of the periods) counted from the latest first release. Each
+// release is a separate instance, listed in the result as a process of its own that is due
+// when the next one is released. The arrived instance with the shortest period runs,
+// preempting any with a longer one, and processes without a period run only when no
+// periodic instance is ready. The running instance keeps the CPU on an equal period, and
+// other ties are broken by selectNext.
+func rateMonotonic(processes []Process, opts Options) Result {
+	instances := periodicInstances(processes)
+
+	return runPreemptive(instances, opts, "shortest period", func(i int, _ []int64) int64 {
+		return instances[i].Period
+	}, func(time int64, remaining []int64, running int) int {
+		return selectNext(instances, func(i int) bool {
+			return instances[i].ArrivalTime <= time && remaining[i] > 0 &&
+				!isSuspended(opts.Suspensions, instances[i].ProcessID, time)
+		}, func(a, b int) int {
+			// a period ranks like a deadline: the shortest first and none last
+			if c := compareDeadlines(instances[a].Period, instances[b].Period); c != 0 {
+				return c
+			}
+			return preferRunning(running, a, b)
+		})
+	})
+}
+
+// hyperperiod returns the least common multiple of the periods of the periodic processes,
+// or 0 if none is periodic.
+func hyperperiod(processes []Process) int64 {
+	var h int64
+	for _, p := range processes {
+		if p.Period <= 0 {
+			continue
+		}
+		if h == 0 {
+			h = p.Period
+			continue
+		}
+		h = lcm(h, p.Period)
+	}
+
+	return h
+}
+
+// periodicInstances expands every periodic process into an instance per release up to the
+// end of the hyperperiod after the latest first release, each due at the next release.
+// Processes without a period are kept as they are.
+func periodicInstances(processes []Process) []Process {
+	h := hyperperiod(processes)
+	var latest int64
+	for _, p := range processes {
+		if p.Period > 0 && p.ArrivalTime > latest {
+			latest = p.ArrivalTime
+		}
+	}
+
+	instances := make([]Process, 0, len(processes))
+	for _, p := range processes {
+		if p.Period <= 0 {
+			instances = append(instances, p)
+			continue
+		}
+		for release := p.ArrivalTime; release < latest+h; release += p.Period {
+			instance := p
+			instance.ArrivalTime = release
+			instance.Deadline = release + p.Period
+			instances = append(instances, instance)
+		}
+	}
+
+	return instances
+}
@@ -0,0 +1,2374 @@
+// Package scheduler simulates CPU scheduling algorithms over a list of processes, and
+// runs the command line interface that reports their schedules.
+package scheduler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Run parses the CLI args, loads the scheduling file and writes every schedule to w,
+// or to the file named by the -append flag. Given several scheduling files it schedules
+// each in turn and ends with each algorithm's averages across them.
+func Run(args []string, w io.Writer) error {
+	cfg, fileArgs, err := parseArgs(args...)
+	if err != nil {
+		return err
+	}
+	if cfg.serveAddr != "" {
+		return serve(cfg.serveAddr)
+	}
+	if cfg.fixturesDir != "" {
+		return checkFixtures(w, cfg.fixturesDir)
+	}
+
+	files, closeFiles, err := openProcessingFile(fileArgs...)
+	if err != nil {
+		return err
+	}
+	defer closeFiles()
+	if len(files) == 1 {
+		_, err = runFile(cfg, files[0], w)
+		return err
+	}
+
+	// with several files each is scheduled in turn, then averaged across them
+	if cfg.format == "json" || cfg.metricsOnly {
+		return fmt.Errorf("%w: -format=json and -metrics-only take a single scheduling file", ErrInvalidArgs)
+	}
+	if cfg.outputPath != "" {
+		if cfg.appendPath != "" {
+			return fmt.Errorf("%w: -output and -append both redirect the results", ErrInvalidArgs)
+		}
+		out, closeOut, err := openOutputFile(cfg.outputPath)
+		if err != nil {
+			return err
+		}
+		defer closeOut()
+		w = out
+		cfg.outputPath = ""
+	}
+	perFile := make([][]Result, 0, len(files))
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		results, err := runFile(cfg, f, w)
+		if err != nil {
+			return fmt.Errorf("%w: scheduling %s", err, f.Name())
+		}
+		perFile = append(perFile, results)
+		names = append(names, f.Name())
+	}
+	// the aggregate follows the files' schedules into the append file
+	if cfg.appendPath != "" {
+		out, closeOut, err := openAppendFile(cfg.appendPath, "aggregate of "+strings.Join(names, ", "))
+		if err != nil {
+			return err
+		}
+		defer closeOut()
+		w = out
+	}
+	outputAggregate(w, perFile)
+
+	return nil
+}
+
+// runFile loads the scheduling file f and writes every schedule to w, or to the file named
+// by the -output or -append flag, returning the results.
+func runFile(cfg config, f *os.File, w io.Writer) ([]Result, error) {
+	// Load and parse processes, hashing the input for the run manifest
+	inputHash := sha256.New()
+	format := cfg.inputFormat
+	if format == "" {
+		format = inputFormatOf(f.Name())
+	}
+	processes, err := loadInput(io.TeeReader(f, inputHash), format, cfg.columns)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateProcesses(processes); err != nil {
+		return nil, err
+	}
+	if len(processes) == 0 {
+		return nil, fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	}
+
+	if cfg.checkSorted {
+		if err := checkSorted(processes); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.outputPath != "" {
+		if cfg.appendPath != "" {
+			return nil, fmt.Errorf("%w: -output and -append both redirect the results", ErrInvalidArgs)
+		}
+		out, closeOut, err := openOutputFile(cfg.outputPath)
+		if err != nil {
+			return nil, err
+		}
+		defer closeOut()
+		w = out
+	}
+	if cfg.appendPath != "" {
+		out, closeOut, err := openAppendFile(cfg.appendPath, f.Name())
+		if err != nil {
+			return nil, err
+		}
+		defer closeOut()
+		w = out
+	}
+
+	// escapes are only worth writing where a terminal shows them as color
+	if cfg.color && !isTerminal(w) {
+		cfg.color = false
+	}
+
+	// the human-readable output goes to w, unless -metrics-only or -format=json holds it
+	// back for -full
+	var full bytes.Buffer
+	human := w
+	if cfg.metricsOnly || cfg.format == "json" {
+		human = &full
+	} else if cfg.fullPath != "" {
+		human = io.MultiWriter(w, &full)
+	}
+
+	if cfg.shuffleSeed >= 0 {
+		processes = shuffleProcesses(processes, cfg.shuffleSeed)
+	}
+
+	if cfg.maxTime >= 0 {
+		var never int
+		if processes, never = withinHorizon(processes, cfg.maxTime); never > 0 {
+			outputNeverArrived(human, never, cfg.maxTime)
+		}
+		if len(processes) == 0 {
+			return nil, fmt.Errorf("%w: no processes arrive within horizon %d", ErrInvalidArgs, cfg.maxTime)
+		}
+	}
+
+	if cfg.preemptGranularity < 1 {
+		return nil, fmt.Errorf("%w: preemption granularity %d, want >= 1", ErrInvalidArgs, cfg.preemptGranularity)
+	}
+	if cfg.quantum < 1 {
+		return nil, fmt.Errorf("%w: round-robin quantum %d, want >= 1", ErrInvalidArgs, cfg.quantum)
+	}
+	if cfg.cpus < 1 {
+		return nil, fmt.Errorf("%w: %d CPUs, want >= 1", ErrInvalidArgs, cfg.cpus)
+	}
+	if cfg.aging < 0 {
+		return nil, fmt.Errorf("%w: aging %d, want >= 0", ErrInvalidArgs, cfg.aging)
+	}
+	opts := Options{
+		Setup:              cfg.setup,
+		DispatchLatency:    cfg.dispatchLatency,
+		PowerDown:          cfg.powerDown,
+		PreemptGranularity: cfg.preemptGranularity,
+		Quantum:            cfg.quantum,
+		RRQuanta:           cfg.rrQuanta,
+		Aging:              cfg.aging,
+		LotterySeed:        cfg.lotterySeed,
+		CPUs:               cfg.cpus,
+		TieBreak:           cfg.tieBreak,
+		MLFQQuanta:         cfg.mlfqQuanta,
+	}
+	if cfg.suspensionsPath != "" {
+		if opts.Suspensions, err = openSuspensions(cfg.suspensionsPath); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.priorityChangesPath != "" {
+		if opts.PriorityChanges, err = openPriorityChanges(cfg.priorityChangesPath); err != nil {
+			return nil, err
+		}
+	}
+	if err := opts.checkCPUs(); err != nil {
+		return nil, err
+	}
+	if cfg.explainSelection {
+		opts.Explain = os.Stderr
+	}
+	if cfg.verbose {
+		opts.Trace = os.Stderr
+	}
+	// bursts are given in work, so schedule the ticks it takes at each service rate
+	scheduled, err := effectiveBursts(processes, cfg.serviceRate)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.autoQuantum {
+		quantum, turnaround, err := bestQuantum(scheduled, opts)
+		if err != nil {
+			return nil, err
+		}
+		opts.Quantum = quantum
+		outputAutoQuantum(human, quantum, turnaround)
+	}
+	results := append(runAlgorithms(cfg.algorithms, scheduled, opts), runAlgorithms(cfg.extra, scheduled, opts)...)
+	if cfg.referencePath != "" {
+		return results, compareAgainst(w, cfg.referencePath, results, cfg)
+	}
+	outputAll(human, results, cfg)
+	if cfg.comparePreemption {
+		outputPreemptionPairs(human, scheduled, opts)
+	}
+	if cfg.fullPath != "" {
+		if err := writeFullFile(cfg.fullPath, full.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.metricsOnly {
+		if err := writeMetricsLine(w, results); err != nil {
+			return nil, err
+		}
+	} else if cfg.format == "json" {
+		if err := writeReports(w, results); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.annotatedPath != "" {
+		if err := writeAnnotatedFile(cfg.annotatedPath, processes, results); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.occupancyPath != "" {
+		if err := writeOccupancyFile(cfg.occupancyPath, results); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.manifestPath != "" {
+		return results, writeManifest(cfg, f.Name(), inputHash.Sum(nil))
+	}
+
+	return results, nil
+}
+
+// config holds the options set by CLI flags.
+type config struct {
+	// inputFormat is the format of the scheduling file, "csv", "xlsx" or "json", or empty to
+	// go by the file extension
+	inputFormat string
+	// columns gives the CSV column each field of a CSV file holds, or nil for CSV order
+	columns      []int
+	appendPath   string
+	checkSorted  bool
+	tabularGantt bool
+	// reverseGantt draws the Gantt chart from the end backward
+	reverseGantt bool
+	// cumulativeAxis adds a Gantt axis of CPU busy time under the wall-clock one
+	cumulativeAxis bool
+	manifestPath   string
+	serveAddr      string
+	color          bool
+	// verbose writes a trace of every tick to stderr
+	verbose bool
+	// explainSelection writes the reason for each dispatch to stderr
+	explainSelection bool
+	setup            SetupTimes
+	dispatchLatency  int64
+	powerDown        PowerDown
+	// algorithms holds the algorithms to run, every default one unless -algorithm names one
+	algorithms []algorithm
+	// extra holds the algorithms named with -with, run after the default ones
+	extra []algorithm
+	// preemptGranularity is how often, in ticks, preemptive algorithms may switch processes
+	preemptGranularity int64
+	// quantum is the round-robin time quantum
+	quantum int64
+	// rrQuanta are round-robin's quanta for each successive turn, replacing quantum
+	rrQuanta []int64
+	// mlfqQuanta are the multilevel feedback queues' time quanta, top queue first
+	mlfqQuanta []int64
+	// aging is how long a process waits before the priority scheduler raises its priority
+	aging int64
+	// lotterySeed seeds the lottery scheduler's drawings
+	lotterySeed int64
+	// cpus is how many CPUs FCFS and SJF schedule on
+	cpus int
+	// tieBreak settles ties in SJF, SRTF and priority scheduling
+	tieBreak string
+	// autoQuantum runs round-robin with the quantum giving the lowest average turnaround
+	autoQuantum bool
+	// serviceRate is the work completed per tick by processes without a rate of their own
+	serviceRate int64
+	// shuffleSeed shuffles the loaded processes before scheduling, or is negative for none
+	shuffleSeed int64
+	// maxTime is the horizon after which arrivals are ignored, or negative for none
+	maxTime int64
+	// outputPath names a file to write the results to instead of stdout
+	outputPath string
+	// suspensionsPath names a CSV of spans during which processes are suspended
+	suspensionsPath string
+	// priorityChangesPath names a CSV of priority changes for the priority scheduler
+	priorityChangesPath string
+	// fixturesDir holds fixtures to check against the current output instead of scheduling
+	fixturesDir string
+	// stackedGantt draws every scheduler's Gantt chart to one time scale under one header
+	stackedGantt bool
+	// comparison follows -algorithm=all with a table comparing the schedulers side by side
+	comparison bool
+	// groupedComparison compares the schedulers in a table per metric category
+	groupedComparison bool
+	waitHistogram     bool
+	intervals         bool
+	showTotals        bool
+	occupancyPath     string
+	annotatedPath     string
+	referencePath     string
+	// format is how the results are written, "text", "markdown" or "json"
+	format string
+	// ganttFormat is how each Gantt chart is drawn, "text" or "svg"
+	ganttFormat string
+	// metricsOnly writes a single line of JSON metrics instead of the human-readable output
+	metricsOnly bool
+	// fullPath names a file to also write the human-readable output to
+	fullPath string
+	// bounds shows each process's turnaround between its least and greatest possible
+	bounds bool
+	// details follows each schedule table with further metrics of the schedule
+	details bool
+	// comparePreemption pairs each preemptive algorithm with its non-preemptive counterpart
+	comparePreemption bool
+	// snapshot prints a sorted, fixed-format listing of every metric for diffing
+	snapshot bool
+	// winners prints only the best scheduler for each metric instead of every schedule
+	winners bool
+	// flags holds every flag's value by name, for recording in the run manifest
+	flags map[string]string
+}
+
+// parseArgs parses the CLI flags, returning the config and the remaining args
+// (prefixed with the program name) for openProcessingFile.
+func parseArgs(args ...string) (config, []string, error) {
+	var cfg config
+	if len(args) == 0 {
+		return cfg, nil, fmt.Errorf("%w: missing program name", ErrInvalidArgs)
+	}
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	inputFormat := func(v string) error {
+		if _, ok := inputLoaders[v]; !ok {
+			return fmt.Errorf("unknown input format %q", v)
+		}
+		cfg.inputFormat = v
+		return nil
+	}
+	fs.Func("input-format", "read the scheduling file as `format` csv, xlsx or json (default from the file extension, else csv)", inputFormat)
+	fs.Func("input", "read the scheduling file as `format`, the same as -input-format", inputFormat)
+	cfg.format = "text"
+	fs.Func("format", "write the results as `format` text, markdown or json (default text)", func(v string) error {
+		if !outputFormats[v] {
+			return fmt.Errorf("unknown output format %q", v)
+		}
+		cfg.format = v
+		return nil
+	})
+	cfg.tieBreak = "arrival"
+	fs.Func("tie-break", "settle ties in SJF, SRTF and priority scheduling by `order` arrival, pid or priority (default arrival)", func(v string) error {
+		if !tieBreaks[v] {
+			return fmt.Errorf("unknown tie-break order %q", v)
+		}
+		cfg.tieBreak = v
+		return nil
+	})
+	fs.Func("gantt-format", "draw the Gantt chart as `format` text or svg (default text)", func(v string) error {
+		if !ganttFormats[v] {
+			return fmt.Errorf("unknown Gantt format %q", v)
+		}
+		cfg.ganttFormat = v
+		return nil
+	})
+	fs.Func("columns", "read CSV fields as the comma separated column `order`, such as pid,arrival,burst,priority", func(v string) error {
+		columns, err := parseColumns(v)
+		cfg.columns = columns
+		return err
+	})
+	fs.StringVar(&cfg.outputPath, "output", "", "write results to `file`, replacing it, instead of to stdout")
+	fs.StringVar(&cfg.appendPath, "append", "", "append results to `file` instead of writing to stdout")
+	fs.BoolVar(&cfg.checkSorted, "check-sorted", false, "fail if processes are not listed in order of arrival")
+	fs.BoolVar(&cfg.tabularGantt, "tabular-gantt", false, "render the Gantt chart as a table of slices")
+	fs.BoolVar(&cfg.reverseGantt, "reverse-gantt", false, "draw the Gantt chart from the end backward, right to left")
+	fs.BoolVar(&cfg.cumulativeAxis, "cumulative-axis", false, "add a Gantt axis of cumulative CPU busy time under the wall-clock one")
+	fs.StringVar(&cfg.manifestPath, "manifest", "", "write a JSON manifest describing the run to `file`")
+	fs.StringVar(&cfg.serveAddr, "serve", "", "serve POST /schedule over HTTP on `addr` instead of reading a file")
+	fs.BoolVar(&cfg.color, "color", false, "color each process's Gantt slices, and processes that missed their deadline red, when writing to a terminal")
+	fs.BoolVar(&cfg.verbose, "verbose", false, "trace to stderr the running and ready processes at every tick")
+	fs.BoolVar(&cfg.explainSelection, "explain-selection", false, "explain to stderr why each process was dispatched")
+	fs.Var((*nonNegative)(&cfg.setup.Default), "setup-time", "take `ticks` to switch between processes of different classes")
+	fs.Func("setup-matrix", "per-class setup times as `from>to=time,...`, overriding -setup-time", func(v string) error {
+		matrix, err := parseSetupMatrix(v)
+		cfg.setup.Matrix = matrix
+		return err
+	})
+	fs.Var((*nonNegative)(&cfg.dispatchLatency), "dispatch-latency", "idle the CPU for `ticks` at the start before the first dispatch")
+	fs.Var((*nonNegative)(&cfg.powerDown.After), "power-down-after", "power the CPU down after it idles for `ticks` (0 never)")
+	fs.Var((*nonNegative)(&cfg.powerDown.Wake), "wake-penalty", "take `ticks` to wake a powered down CPU for the next process")
+	cfg.algorithms = schedulers
+	fs.Func("algorithm", "run only the named `algorithm` (fcfs, sjf, priority, rr, one named by -with, or all)", func(v string) error {
+		if v == "all" {
+			cfg.algorithms = schedulers
+			cfg.comparison = true
+			return nil
+		}
+		a, ok := namedSchedulers[v]
+		if !ok {
+			if a, ok = extraSchedulers[v]; !ok {
+				return fmt.Errorf("unknown algorithm %q", v)
+			}
+		}
+		cfg.algorithms = []algorithm{a}
+		return nil
+	})
+	fs.Func("with", "also run the comma separated `algorithms` (wfq, sjf-np, sjf-lookahead, srtf, hrrn, ljf, lrtf, mlfq, edf, rm, lottery)", func(v string) error {
+		for _, name := range strings.Split(v, ",") {
+			a, ok := extraSchedulers[strings.TrimSpace(name)]
+			if !ok {
+				return fmt.Errorf("unknown algorithm %q", name)
+			}
+			cfg.extra = append(cfg.extra, a)
+		}
+		return nil
+	})
+	fs.Int64Var(&cfg.preemptGranularity, "preempt-granularity", 1, "let preemptive algorithms switch processes only every `ticks` ticks")
+	fs.Int64Var(&cfg.quantum, "quantum", defaultQuantum, "run round-robin with a time quantum of `ticks`")
+	fs.Func("quantum-per-queue", "give round-robin turns comma separated quanta, a process's nth turn using the nth `quantum` (or the last), instead of -quantum", func(v string) error {
+		quanta, err := parseQuanta(v)
+		cfg.rrQuanta = quanta
+		return err
+	})
+	fs.Func("mlfq-quanta", "give the multilevel feedback queue one queue per comma separated `quantum`, top first (default 2,4,8)", func(v string) error {
+		quanta, err := parseQuanta(v)
+		cfg.mlfqQuanta = quanta
+		return err
+	})
+	fs.Int64Var(&cfg.aging, "aging", 0, "raise a waiting process's priority by one every `ticks` ticks it waits (0 for no aging)")
+	fs.IntVar(&cfg.cpus, "cpus", 1, "schedule FCFS and SJF on `n` CPUs, without suspensions, setup times or power-down")
+	fs.Int64Var(&cfg.lotterySeed, "lottery-seed", 0, "seed the lottery scheduler's drawings with `seed`")
+	fs.BoolVar(&cfg.autoQuantum, "auto-quantum", false, "run round-robin with the quantum, up to the longest burst, giving the lowest average turnaround")
+	fs.Int64Var(&cfg.serviceRate, "service-rate", 1, "work completed per tick, turning each burst into ceil(burst / `rate`) ticks")
+	fs.Int64Var(&cfg.shuffleSeed, "shuffle-input", -1, "shuffle the loaded processes with `seed` before scheduling, to check order independence (negative for none)")
+	fs.Int64Var(&cfg.maxTime, "maxtime", -1, "ignore processes arriving after `time`, reporting how many never arrived (negative for no limit)")
+	fs.StringVar(&cfg.suspensionsPath, "suspensions", "", "suspend processes using pid,from,to rows from `file`")
+	fs.StringVar(&cfg.priorityChangesPath, "priority-changes", "", "change priorities over time using pid,time,priority rows from `file`")
+	fs.StringVar(&cfg.fixturesDir, "check-fixtures", "", "report which fixtures in `dir` no longer match the output for their input")
+	fs.BoolVar(&cfg.groupedComparison, "grouped-comparison", false, "compare the algorithms in a table per metric category")
+	fs.BoolVar(&cfg.stackedGantt, "stacked-gantt", false, "also draw every algorithm's Gantt chart stacked on one time axis")
+	fs.BoolVar(&cfg.showTotals, "show-totals", false, "show total wait and turnaround alongside the averages")
+	fs.BoolVar(&cfg.details, "details", false, "follow each schedule table with its wait stddev, Little's law check, utilization, context switches, peak backlog, stretch and order preservation")
+	fs.BoolVar(&cfg.bounds, "bounds", false, "show each turnaround between its minimum (the burst) and maximum (the total work)")
+	fs.BoolVar(&cfg.waitHistogram, "wait-histogram", false, "print a histogram of waiting times for each algorithm")
+	fs.BoolVar(&cfg.intervals, "intervals", false, "list each process's [start,stop] running and waiting intervals")
+	fs.StringVar(&cfg.occupancyPath, "occupancy-csv", "", "write the PID on the CPU at every time unit to `file` as CSV")
+	fs.StringVar(&cfg.annotatedPath, "annotated-csv", "", "write the input with each algorithm's wait, turnaround and completion appended to `file`")
+	fs.StringVar(&cfg.referencePath, "compare-against", "", "diff the output against the reference `file`, failing if any line differs")
+	fs.BoolVar(&cfg.metricsOnly, "metrics-only", false, "write only a single line of JSON metrics to stdout")
+	fs.StringVar(&cfg.fullPath, "full", "", "also write the human-readable output to `file`")
+	fs.BoolVar(&cfg.comparePreemption, "compare-preemptive-vs-nonpreemptive", false, "compare each preemptive algorithm with its non-preemptive counterpart")
+	fs.BoolVar(&cfg.snapshot, "snapshot", false, "print only a sorted, deterministic listing of every metric, for diffing between versions")
+	fs.BoolVar(&cfg.winners, "winners", false, "print only the winning algorithm for each metric")
+	if err := fs.Parse(args[1:]); err != nil {
+		return cfg, nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	cfg.flags = make(map[string]string)
+	fs.VisitAll(func(f *flag.Flag) {
+		cfg.flags[f.Name] = f.Value.String()
+	})
+
+	return cfg, append([]string{args[0]}, fs.Args()...), nil
+}
+
+// nonNegative is an int64 flag value that cannot be set below zero.
+type nonNegative int64
+
+func (n *nonNegative) String() string {
+	return strconv.FormatInt(int64(*n), 10)
+}
+
+func (n *nonNegative) Set(v string) error {
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return err
+	}
+	if i < 0 {
+		return fmt.Errorf("%d is negative", i)
+	}
+	*n = nonNegative(i)
+
+	return nil
+}
+
+// openProcessingFile opens every scheduling file named after the program name, so that a
+// missing one is reported before any is scheduled.
+func openProcessingFile(args ...string) ([]*os.File, func(), error) {
+	if len(args) < 2 {
+		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+	}
+	files := make([]*os.File, 0, len(args)-1)
+	closeFn := func() {
+		for _, f := range files {
+			if err := f.Close(); err != nil {
+				log.Fatalf("%v: error closing scheduling file", err)
+			}
+		}
+	}
+	for _, name := range args[1:] {
+		// Read in CSV process CSV file
+		f, err := os.Open(name)
+		if err != nil {
+			closeFn()
+			return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+		}
+		files = append(files, f)
+	}
+
+	return files, closeFn, nil
+}
+
+// openOutputFile creates, or truncates, the file the results are written to.
+func openOutputFile(name string) (*os.File, func(), error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening output file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("%v: error closing output file", err)
+		}
+	}
+
+	return f, closeFn, nil
+}
+
+// openAppendFile opens (creating if needed) an output file for appending and writes
+// a header separating this run from any earlier ones.
+func openAppendFile(name, input string) (*os.File, func(), error) {
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening append file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("%v: error closing append file", err)
+		}
+	}
+
+	header := fmt.Sprintf("Run at %s: %s", time.Now().Format(time.RFC3339), input)
+	if _, err := fmt.Fprintf(f, "%s\n%s\n%s\n", strings.Repeat("=", len(header)), header, strings.Repeat("=", len(header))); err != nil {
+		closeFn()
+		return nil, nil, fmt.Errorf("%v: error writing append file", err)
+	}
+
+	return f, closeFn, nil
+}
+
+type (
+	Process struct {
+		ProcessID     int64 `json:"pid"`
+		ArrivalTime   int64 `json:"arrival"`
+		BurstDuration int64 `json:"burst"`
+		Priority      int64 `json:"priority"`
+		// Deadline is the time by which the process should complete, or 0 for none.
+		Deadline int64 `json:"deadline,omitempty"`
+		// Class groups processes that can follow each other without setup time.
+		Class string `json:"class,omitempty"`
+		// Weight sets the process's share of the CPU under weighted fair queuing; 0 counts as 1.
+		Weight int64 `json:"weight,omitempty"`
+		// Rate is the work the process completes per tick, which makes its burst a
+		// count of work rather than ticks; 0 uses the -service-rate.
+		Rate int64 `json:"rate,omitempty"`
+		// Quota caps the CPU time the process may use; once it is used up the process is
+		// terminated even if its burst is unfinished. 0 means no quota.
+		Quota int64 `json:"quota,omitempty"`
+		// Period makes the process a periodic task, released again every Period ticks
+		// under rate monotonic scheduling, or 0 for a one-off process.
+		Period int64 `json:"period,omitempty"`
+		// Tickets are the process's entries in each lottery drawing; an unset count is 1.
+		Tickets int64 `json:"tickets,omitempty"`
+	}
+	TimeSlice struct {
+		PID   int64 `json:"pid"`
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+	}
+	// Result holds the per-process timings and Gantt chart produced by a scheduler.
+	Result struct {
+		Title      string      `json:"title,omitempty"`
+		Processes  []Process   `json:"processes"`
+		Wait       []int64     `json:"wait"`
+		Turnaround []int64     `json:"turnaround"`
+		Completion []int64     `json:"completion"`
+		Gantt      []TimeSlice `json:"gantt"`
+		Throughput float64     `json:"throughput"`
+		// Cores holds each CPU's Gantt chart when the processes were scheduled on several.
+		Cores [][]TimeSlice `json:"cores,omitempty"`
+		// Shares compares the CPU each process received with its weight, for schedulers
+		// that divide the CPU by weight.
+		Shares []Share `json:"shares,omitempty"`
+		// Wins counts the drawings each process won, for lottery scheduling.
+		Wins []int64 `json:"wins,omitempty"`
+		// Convoys lists short jobs stuck behind long ones, for schedulers prone to it.
+		Convoys []Convoy `json:"convoys,omitempty"`
+		// SameAsFCFS is set when a scheduler ran every process in the order FCFS would.
+		SameAsFCFS bool `json:"same_as_fcfs,omitempty"`
+	}
+)
+
+// Options tune how the schedulers run.
+type Options struct {
+	// Explain, if set, receives a line for every dispatch saying why that process was chosen.
+	Explain io.Writer `json:"-"`
+	// Trace, if set, receives a line for every tick of each schedule run by the command,
+	// giving the running process and those ready.
+	Trace io.Writer `json:"-"`
+	// Setup is the time spent switching the CPU between processes of different classes.
+	Setup SetupTimes `json:"setup"`
+	// DispatchLatency keeps the CPU idle for this long after the first arrival, modelling
+	// the scheduler starting up before it can dispatch anything.
+	DispatchLatency int64 `json:"dispatch_latency"`
+	// PriorityChanges alter process priorities while the priority scheduler runs.
+	PriorityChanges []PriorityChange `json:"priority_changes,omitempty"`
+	// PowerDown switches the CPU off when it idles, delaying the next process to arrive.
+	PowerDown PowerDown `json:"power_down"`
+	// Suspensions take processes out of contention for a while; suspended time is not
+	// counted as waiting.
+	Suspensions []Suspension `json:"suspensions,omitempty"`
+	// PreemptGranularity is how often, in ticks, the preemptive schedulers reconsider
+	// the running process; 0 or 1 means every tick.
+	PreemptGranularity int64 `json:"preempt_granularity,omitempty"`
+	// Quantum is the most round-robin runs a process before moving to the next; 0 uses
+	// defaultQuantum.
+	Quantum int64 `json:"quantum,omitempty"`
+	// RRQuanta, if set, replace Quantum with a quantum that changes with each turn: a
+	// process's turn after it has run n times lasts RRQuanta[min(n, len(RRQuanta)-1)].
+	RRQuanta []int64 `json:"rr_quanta,omitempty"`
+	// MLFQQuanta are the time quanta of the multilevel feedback queues, top queue first;
+	// nil uses defaultMLFQQuanta.
+	MLFQQuanta []int64 `json:"mlfq_quanta,omitempty"`
+	// Aging raises a process's priority by one for every Aging ticks it has spent waiting
+	// in the priority scheduler; 0 disables aging.
+	Aging int64 `json:"aging,omitempty"`
+	// LotterySeed seeds the drawings of the lottery scheduler, so a seed always gives the
+	// same schedule.
+	LotterySeed int64 `json:"lottery_seed,omitempty"`
+	// CPUs is how many CPUs FCFS and SJF schedule on; 0 or 1 means one. More than one
+	// cannot be combined with Suspensions, Setup or PowerDown.
+	CPUs int `json:"cpus,omitempty"`
+	// TieBreak settles ties in SJF, SRTF and priority scheduling by "arrival", "pid" or
+	// "priority"; empty means "arrival".
+	TieBreak string `json:"tie_break,omitempty"`
+	// OnTick, if set, is called by the preemptive schedulers (SJF, SRTF, priority,
+	// round-robin, LRTF, MLFQ, EDF, rate monotonic, WFQ and lottery) for every tick once
+	// they start dispatching, with the process on the CPU (idlePID if none) and the IDs of
+	// the others ready to run. It is not called when scheduling on more than one CPU.
+	OnTick func(time int64, runningPID int64, ready []int64) `json:"-"`
+}
+
+// defaultQuantum is the round-robin time quantum used unless another is chosen.
+const defaultQuantum = 2
+
+// quantum returns the round-robin time quantum to use.
+func (o Options) quantum() int64 {
+	if o.Quantum <= 0 {
+		return defaultQuantum
+	}
+
+	return o.Quantum
+}
+
+// rrQuantum returns the round-robin time quantum for a process that has run runs times.
+func (o Options) rrQuantum(runs int) int64 {
+	if len(o.RRQuanta) == 0 {
+		return o.quantum()
+	}
+	if runs >= len(o.RRQuanta) {
+		runs = len(o.RRQuanta) - 1
+	}
+
+	return o.RRQuanta[runs]
+}
+
+// scheduleFunc computes a schedule for processes, or returns why it cannot.
+type scheduleFunc func(processes []Process, opts Options) (Result, error)
+
+// infallible adapts a scheduler that always finishes to a scheduleFunc.
+func infallible(schedule func(processes []Process, opts Options) Result) scheduleFunc {
+	return func(processes []Process, opts Options) (Result, error) {
+		return schedule(processes, opts), nil
+	}
+}
+
+// algorithm is a titled scheduling algorithm.
+type algorithm struct {
+	title    string
+	schedule scheduleFunc
+}
+
+// schedulers lists the scheduling algorithms run by default in the order they are reported.
+var schedulers = []algorithm{
+	{"First-come, first-serve", infallible(FCFS)},
+	{"Shortest-job-first", infallible(SJF)},
+	{"Priority", infallible(SJFPriority)},
+	{"Round-robin", RoundRobin},
+}
+
+// namedSchedulers maps the name -algorithm accepts for each default algorithm to it.
+var namedSchedulers = map[string]algorithm{
+	"fcfs":     schedulers[0],
+	"sjf":      schedulers[1],
+	"priority": schedulers[2],
+	"rr":       schedulers[3],
+}
+
+// extraSchedulers are run after the default ones when named with -with.
+var extraSchedulers = map[string]algorithm{
+	"wfq":           {"Weighted fair queuing", infallible(wfq)},
+	"sjf-np":        {"Shortest-job-first (non-preemptive)", infallible(sjfNonPreemptive)},
+	"sjf-lookahead": {"Shortest-job-first (lookahead)", infallible(sjfLookahead)},
+	"srtf":          {"Shortest-remaining-time-first", infallible(sjfEventDriven)},
+	"hrrn":          {"Highest response ratio next", infallible(hrrn)},
+	"ljf":           {"Longest-job-first", infallible(ljf)},
+	"lrtf":          {"Longest-remaining-time-first", infallible(lrtf)},
+	"mlfq":          {"Multilevel feedback queue", infallible(mlfq)},
+	"edf":           {"Earliest deadline first", infallible(edf)},
+	"rm":            {"Rate monotonic", infallible(rateMonotonic)},
+	"lottery":       {"Lottery", infallible(lottery)},
+}
+
+// RunAll schedules the processes with every default algorithm, returning the titled results.
+func RunAll(processes []Process, opts Options) []Result {
+	return runAlgorithms(schedulers, processes, opts)
+}
+
+// runAlgorithms schedules the processes with each algorithm in turn, returning the titled
+// results. An algorithm that fails or panics is logged and left out, so the others still
+// report.
+func runAlgorithms(algorithms []algorithm, processes []Process, opts Options) []Result {
+	results := make([]Result, 0, len(algorithms))
+	for _, a := range algorithms {
+		res, err := runSafely(a, processes, opts)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		results = append(results, res)
+	}
+
+	return results
+}
+
+// runSafely schedules the processes with a, returning its error prefixed with its title,
+// and turning a panic into ErrSchedulerPanic.
+func runSafely(a algorithm, processes []Process, opts Options) (res Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %s: %v", ErrSchedulerPanic, a.title, r)
+		}
+	}()
+	res, err = a.schedule(processes, opts)
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", a.title, err)
+	}
+	res.Title = a.title
+	if opts.Trace != nil {
+		writeTrace(opts.Trace, res, opts.Suspensions)
+	}
+
+	return res, nil
+}
+
+//region Schedulers
+
+// Time advances in whole ticks. When a process arrives at the same tick another one
+// completes (or uses up its round-robin quantum), the arrival joins the ready queue
+// first and only then does the scheduler pick what runs next, so the new arrival is
+// a candidate for that dispatch. A preempted round-robin process rejoins the queue
+// behind anything that arrived at the tick it was preempted.
+
+// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// It returns the result, so callers need not parse the output. Pass io.Discard as the
+// writer to only compute it.
+func FCFSSchedule(w io.Writer, title string, processes []Process) Result {
+	res := FCFS(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// FCFS runs each process to completion in the order given.
+func FCFS(processes []Process, opts Options) Result {
+	if opts.cpus() > 1 {
+		return fcfsMulti(processes, opts)
+	}
+	var (
+		serviceTime int64
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+		gantt       = make([]TimeSlice, 0)
+	)
+	if len(processes) > 0 {
+		serviceTime = dispatchStart(processes, opts.DispatchLatency)
+	}
+	// serve processes in order of arrival, whatever order they are listed in
+	order := arrivalOrder(processes)
+	for k, i := range order {
+		// the CPU sits idle until the next process arrives and isn't suspended
+		if processes[i].ArrivalTime > serviceTime {
+			serviceTime = processes[i].ArrivalTime
+		}
+		serviceTime = resumeTime(opts.Suspensions, processes[i].ProcessID, serviceTime)
+		serviceTime += opts.PowerDown.wakeDelay(gantt, serviceTime)
+		if k > 0 {
+			serviceTime += opts.Setup.between(processes[order[k-1]].Class, processes[i].Class)
+		}
+		if opts.Explain != nil {
+			var waiting []string
+			for _, j := range order[k:] {
+				if processes[j].ArrivalTime > serviceTime {
+					break
+				}
+				waiting = append(waiting, fmt.Sprintf("P%d:%d", processes[j].ProcessID, processes[j].ArrivalTime))
+			}
+			explainDispatch(opts.Explain, serviceTime, processes[i].ProcessID,
+				fmt.Sprintf("next in arrival order (%d)", processes[i].ArrivalTime), waiting)
+		}
+
+		// run the burst, leaving the CPU idle while the process is suspended
+		for remaining := processes[i].cpuTime(); remaining > 0; {
+			stop := serviceTime + remaining
+			if suspend := nextSuspension(opts.Suspensions, processes[i].ProcessID, serviceTime); suspend < stop {
+				stop = suspend
+			}
+			gantt = append(gantt, TimeSlice{
+				PID:   processes[i].ProcessID,
+				Start: serviceTime,
+				Stop:  stop,
+			})
+			remaining -= stop - serviceTime
+			serviceTime = stop
+			if remaining > 0 {
+				serviceTime = resumeTime(opts.Suspensions, processes[i].ProcessID, serviceTime)
+			}
+		}
+
+		completions[i] = serviceTime
+		turnArounds[i] = completions[i] - processes[i].ArrivalTime
+		waitTimes[i] = turnArounds[i] - processes[i].cpuTime() -
+			suspendedFor(opts.Suspensions, processes[i].ProcessID, processes[i].ArrivalTime, completions[i])
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+		Convoys:    convoys(processes, gantt),
+	}
+}
+
+func SJFSchedule(w io.Writer, title string, processes []Process) Result {
+	res := SJF(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// SJF runs whichever arrived process has the least remaining time, one tick at a time.
+func SJF(processes []Process, opts Options) Result {
+	if opts.cpus() > 1 {
+		return sjfMulti(processes, opts)
+	}
+	var (
+		total         int   = 0
+		shortest      int64 = 0
+		last          int64 = -1
+		time          int64
+		check         bool = false
+		gantt              = make([]TimeSlice, 0)
+		recordedTimes      = make([]int64, len(processes))
+		waitTimes          = make([]int64, len(processes))
+		turnArounds        = make([]int64, len(processes))
+		completions        = make([]int64, len(processes))
+	)
+
+	// copy burst durations for tracking
+	for i := range processes {
+		recordedTimes[i] = processes[i].cpuTime()
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+
+	// run until all processes are complete
+	for total != len(processes) {
+
+		// a suspended process gives up the CPU
+		if check && isSuspended(opts.Suspensions, processes[shortest].ProcessID, time) {
+			check = false
+		}
+
+		// find process with minimum remaining time, keeping the running process on a
+		// tie; between preemption points the running process keeps the CPU
+		if !check || opts.preemptionPoint(time) {
+			running := -1
+			if check {
+				running = int(shortest)
+			}
+			next := selectNext(processes, func(i int) bool {
+				return processes[i].ArrivalTime <= time && recordedTimes[i] > 0 &&
+					!isSuspended(opts.Suspensions, processes[i].ProcessID, time)
+			}, func(a, b int) int {
+				if c := compareInt64(recordedTimes[a], recordedTimes[b]); c != 0 {
+					return c
+				}
+				if c := preferRunning(running, a, b); c != 0 {
+					return c
+				}
+				return opts.tieBreak(processes, a, b)
+			})
+			if next != -1 {
+				shortest = int64(next)
+				check = true
+			}
+		}
+
+		// if no process is ready
+		if check == false {
+			opts.onTicks(time, time+1, idlePID, readyPIDs(processes, recordedTimes, opts, time, idlePID))
+			time++
+			continue
+		}
+
+		if opts.Explain != nil && isDispatch(gantt, processes[shortest].ProcessID, time) {
+			explainDispatch(opts.Explain, time, processes[shortest].ProcessID,
+				fmt.Sprintf("shortest remaining (%d)", recordedTimes[shortest]),
+				readyLabels(processes, recordedTimes, time, func(i int) string {
+					return fmt.Sprintf("P%d:%d", processes[i].ProcessID, recordedTimes[i])
+				}))
+		}
+
+		// a powered down CPU has to wake before the process can run
+		dispatched := time
+		time += opts.PowerDown.wakeDelay(gantt, time)
+
+		// switching class costs setup time before the process can run
+		if last != -1 {
+			time += opts.Setup.between(processes[last].Class, processes[shortest].Class)
+		}
+		last = shortest
+		opts.onTicks(dispatched, time, idlePID, readyPIDs(processes, recordedTimes, opts, dispatched, idlePID))
+
+		// run the process for one tick
+		pid := processes[shortest].ProcessID
+		opts.onTicks(time, time+1, pid, readyPIDs(processes, recordedTimes, opts, time, pid))
+		gantt = runTick(gantt, pid, time)
+		recordedTimes[shortest]--
+		time++
+
+		// if fully executed
+		if recordedTimes[shortest] == 0 {
+			total++
+			check = false
+			completions[shortest] = time
+			waitTimes[shortest] = time - processes[shortest].cpuTime() - processes[shortest].ArrivalTime -
+				suspendedFor(opts.Suspensions, processes[shortest].ProcessID, processes[shortest].ArrivalTime, time)
+		}
+	}
+
+	// calculate turnarounds
+	for i := range processes {
+		turnArounds[i] = completions[i] - processes[i].ArrivalTime
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+		SameAsFCFS: sameAsFCFS(processes, opts, gantt),
+	}
+}
+
+// PriorityPreemptiveSchedule writes the preemptive priority schedule of the processes to w.
+func PriorityPreemptiveSchedule(w io.Writer, title string, processes []Process) Result {
+	res := SJFPriority(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// SJFPrioritySchedule is PriorityPreemptiveSchedule under its old name.
+//
+// Deprecated: it schedules by priority first, not by shortest job; use
+// PriorityPreemptiveSchedule.
+func SJFPrioritySchedule(w io.Writer, title string, processes []Process) Result {
+	return PriorityPreemptiveSchedule(w, title, processes)
+}
+
+// SJFPriority favours arrived processes by priority, then by least remaining time. With
+// Options.Aging set, the priority it compares improves the longer a process waits; the
+// processes' own Priority is left as it was.
+func SJFPriority(processes []Process, opts Options) Result {
+	var (
+		total         int   = 0
+		curr          int64 = 0
+		last          int64 = -1
+		time          int64
+		check         bool = false
+		gantt              = make([]TimeSlice, 0)
+		recordedTimes      = make([]int64, len(processes))
+		waitTimes          = make([]int64, len(processes))
+		turnArounds        = make([]int64, len(processes))
+		completions        = make([]int64, len(processes))
+	)
+
+	// copy burst durations for tracking
+	for i := range processes {
+		recordedTimes[i] = processes[i].cpuTime()
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+
+	// priority gives a process's priority at the current time, raised by aging
+	priority := func(i int64) int64 {
+		waited := time - processes[i].ArrivalTime - (processes[i].cpuTime() - recordedTimes[i])
+		return priorityAt(processes[i], opts.PriorityChanges, time) - opts.agingBoost(waited)
+	}
+
+	// run until all processes are complete
+	for total != len(processes) {
+
+		// a suspended process gives up the CPU
+		if check && isSuspended(opts.Suspensions, processes[curr].ProcessID, time) {
+			check = false
+		}
+
+		// find process with highest priority, then minimum remaining time, keeping the
+		// running process on a tie; re-evaluated at every preemption point so a change of
+		// priority takes effect at the next one
+		if !check || opts.preemptionPoint(time) {
+			running := -1
+			if check {
+				running = int(curr)
+			}
+			next := selectNext(processes, func(i int) bool {
+				return processes[i].ArrivalTime <= time && recordedTimes[i] > 0 &&
+					!isSuspended(opts.Suspensions, processes[i].ProcessID, time)
+			}, func(a, b int) int {
+				if c := compareInt64(priority(int64(a)), priority(int64(b))); c != 0 {
+					return c
+				}
+				if c := compareInt64(recordedTimes[a], recordedTimes[b]); c != 0 {
+					return c
+				}
+				if c := preferRunning(running, a, b); c != 0 {
+					return c
+				}
+				return opts.tieBreak(processes, a, b)
+			})
+			if next != -1 {
+				curr = int64(next)
+				check = true
+			}
+		}
+
+		// if no process is ready
+		if check == false {
+			opts.onTicks(time, time+1, idlePID, readyPIDs(processes, recordedTimes, opts, time, idlePID))
+			time++
+			continue
+		}
+
+		if opts.Explain != nil && isDispatch(gantt, processes[curr].ProcessID, time) {
+			explainDispatch(opts.Explain, time, processes[curr].ProcessID,
+				fmt.Sprintf("priority (%d) then remaining (%d)", priority(curr), recordedTimes[curr]),
+				readyLabels(processes, recordedTimes, time, func(i int) string {
+					return fmt.Sprintf("P%d:%d/%d", processes[i].ProcessID, priority(int64(i)), recordedTimes[i])
+				}))
+		}
+
+		// a powered down CPU has to wake before the process can run
+		dispatched := time
+		time += opts.PowerDown.wakeDelay(gantt, time)
+
+		// switching class costs setup time before the process can run
+		if last != -1 {
+			time += opts.Setup.between(processes[last].Class, processes[curr].Class)
+		}
+		last = curr
+		opts.onTicks(dispatched, time, idlePID, readyPIDs(processes, recordedTimes, opts, dispatched, idlePID))
+
+		// run the process for one tick
+		pid := processes[curr].ProcessID
+		opts.onTicks(time, time+1, pid, readyPIDs(processes, recordedTimes, opts, time, pid))
+		gantt = runTick(gantt, pid, time)
+		recordedTimes[curr]--
+		time++
+
+		// if fully executed
+		if recordedTimes[curr] == 0 {
+			total++
+			check = false
+			completions[curr] = time
+			waitTimes[curr] = time - processes[curr].cpuTime() - processes[curr].ArrivalTime -
+				suspendedFor(opts.Suspensions, processes[curr].ProcessID, processes[curr].ArrivalTime, time)
+		}
+	}
+
+	// calculate turnarounds
+	for i := range processes {
+		turnArounds[i] = completions[i] - processes[i].ArrivalTime
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+	}
+}
+
+// RRSchedule writes the round-robin schedule of the processes with the default quantum to w.
+func RRSchedule(w io.Writer, title string, processes []Process) (Result, error) {
+	return RRScheduleQuantum(w, title, processes, defaultQuantum)
+}
+
+// RRScheduleQuantum is RRSchedule with the given time quantum, at least one tick.
+func RRScheduleQuantum(w io.Writer, title string, processes []Process, quantum int64) (Result, error) {
+	if quantum < 1 {
+		return Result{}, fmt.Errorf("%w: round-robin quantum %d, want >= 1", ErrInvalidArgs, quantum)
+	}
+	res, err := RoundRobin(processes, Options{Quantum: quantum})
+	if err != nil {
+		return Result{}, err
+	}
+	outputResult(w, title, res, config{})
+
+	return res, nil
+}
+
+// RRScheduleVariable is RRSchedule with a process's quantum taken from quanta by its turn.
+func RRScheduleVariable(w io.Writer, title string, processes []Process, quanta []int64) (Result, error) {
+	res, err := RoundRobin(processes, Options{RRQuanta: quanta})
+	if err != nil {
+		return Result{}, err
+	}
+	outputResult(w, title, res, config{})
+
+	return res, nil
+}
+
+// RoundRobin cycles through arrived processes, running each for up to a time quantum, fixed
+// unless Options.RRQuanta lengthens it with every turn. It returns ErrInvalidArgs for a
+// quantum below one tick, and ErrNoProgress if it runs far past any time the processes could
+// need, as when one is suspended for ages.
+func RoundRobin(processes []Process, opts Options) (Result, error) {
+	for _, q := range opts.RRQuanta {
+		if q < 1 {
+			return Result{}, fmt.Errorf("%w: round-robin quantum %d, want >= 1", ErrInvalidArgs, q)
+		}
+	}
+	// the ready queue is seeded from the first process listed and joined in list order,
+	// so schedule the processes in arrival order and report them in the order given
+	order := arrivalOrder(processes)
+	sorted := make([]Process, len(processes))
+	for k, i := range order {
+		sorted[k] = processes[i]
+	}
+	res, err := rrArrivalOrdered(sorted, opts)
+	if err != nil {
+		return Result{}, err
+	}
+
+	unsorted := res
+	unsorted.Processes = processes
+	unsorted.Wait = make([]int64, len(processes))
+	unsorted.Turnaround = make([]int64, len(processes))
+	unsorted.Completion = make([]int64, len(processes))
+	for k, i := range order {
+		unsorted.Wait[i] = res.Wait[k]
+		unsorted.Turnaround[i] = res.Turnaround[k]
+		unsorted.Completion[i] = res.Completion[k]
+	}
+
+	return unsorted, nil
+}
+
+// rrArrivalOrdered is RoundRobin for processes listed in order of arrival, starting from the
+// arrival of the first.
+func rrArrivalOrdered(processes []Process, opts Options) (Result, error) {
+	// the queue starts from the first process, so with none there is nothing to run
+	if len(processes) == 0 {
+		return Result{
+			Processes:  processes,
+			Wait:       []int64{},
+			Turnaround: []int64{},
+			Completion: []int64{},
+			Gantt:      []TimeSlice{},
+		}, nil
+	}
+
+	var (
+		time        = processes[0].ArrivalTime
+		arrived     int
+		finished    int
+		last        = -1
+		gantt       = make([]TimeSlice, 0)
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+		remaining   = make([]int64, len(processes))
+		// runs counts the turns each process has had on the CPU
+		runs = make([]int, len(processes))
+		// queue holds the indices of the processes waiting for the CPU, front first
+		queue = make([]int, 0, len(processes))
+	)
+	for i := range processes {
+		remaining[i] = processes[i].cpuTime()
+	}
+
+	// enqueueArrivals adds every process that has arrived by now to the back of the queue
+	enqueueArrivals := func() {
+		for arrived < len(processes) && processes[arrived].ArrivalTime <= time {
+			queue = append(queue, arrived)
+			arrived++
+		}
+	}
+	// idleTick passes a tick with nothing running
+	idleTick := func() {
+		opts.onTicks(time, time+1, idlePID, readyPIDs(processes, remaining, opts, time, idlePID))
+		time++
+		enqueueArrivals()
+	}
+	// suspended reports whether the process at index i is suspended now
+	suspended := func(i int) bool {
+		return isSuspended(opts.Suspensions, processes[i].ProcessID, time)
+	}
+
+	enqueueArrivals()
+	// the dispatcher starts up before anything runs
+	for latency := opts.DispatchLatency; latency > 0; latency-- {
+		time++
+		enqueueArrivals()
+	}
+
+	limit := rrTimeLimit(processes, opts)
+	// stalled counts the turns handed out since time last moved; every process getting
+	// one without any running means none ever will
+	stalled, stalledAt := 0, time
+	for finished != len(processes) {
+		if time > limit {
+			return Result{}, fmt.Errorf("%w: round-robin passed time %d with %d of %d processes unfinished",
+				ErrNoProgress, limit, len(processes)-finished, len(processes))
+		}
+		if time != stalledAt {
+			stalled, stalledAt = 0, time
+		}
+		if stalled++; stalled > len(processes) {
+			return Result{}, fmt.Errorf("%w: round-robin stuck at time %d with %d of %d processes unfinished",
+				ErrNoProgress, time, len(processes)-finished, len(processes))
+		}
+		// suspended processes give up their turn to the first one that can run
+		ready := -1
+		for k, i := range queue {
+			if !suspended(i) {
+				ready = k
+				break
+			}
+		}
+		if ready == -1 {
+			idleTick()
+			continue
+		}
+		queue = append(queue[ready:], queue[:ready]...)
+		next := queue[0]
+		queue = queue[1:]
+
+		if opts.Explain != nil && isDispatch(gantt, processes[next].ProcessID, time) {
+			var queued []string
+			for _, i := range append([]int{next}, queue...) {
+				queued = append(queued, fmt.Sprintf("P%d:%d", processes[i].ProcessID, remaining[i]))
+			}
+			explainDispatch(opts.Explain, time, processes[next].ProcessID,
+				fmt.Sprintf("front of the ready queue, remaining (%d)", remaining[next]), queued)
+		}
+		// a powered down CPU has to wake, and switching class costs setup time, before
+		// the process can run
+		for wake := opts.PowerDown.wakeDelay(gantt, time); wake > 0; wake-- {
+			idleTick()
+		}
+		if last != -1 {
+			for setup := opts.Setup.between(processes[last].Class, processes[next].Class); setup > 0; setup-- {
+				idleTick()
+			}
+		}
+
+		// new arrivals during the quantum join the queue ahead of the preempted process
+		tq := opts.rrQuantum(runs[next])
+		for curr := int64(0); curr < tq && remaining[next] > 0 && !suspended(next); curr++ {
+			if curr == 0 {
+				runs[next]++
+			}
+			last = next
+			pid := processes[next].ProcessID
+			opts.onTicks(time, time+1, pid, readyPIDs(processes, remaining, opts, time, pid))
+			gantt = runTick(gantt, pid, time)
+			remaining[next]--
+			time++
+			enqueueArrivals()
+		}
+		if remaining[next] > 0 {
+			queue = append(queue, next)
+			continue
+		}
+
+		finished++
+		completions[next] = time
+		turnArounds[next] = completions[next] - processes[next].ArrivalTime
+		// time the CPU sat idle while the process was in the system was spent
+		// suspended, so only a negative remainder is impossible
+		waitTimes[next] = turnArounds[next] - processes[next].cpuTime() -
+			suspendedFor(opts.Suspensions, processes[next].ProcessID, processes[next].ArrivalTime, completions[next])
+		if waitTimes[next] < 0 {
+			waitTimes[next] = 0
+		}
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+	}, nil
+}
+
+// rrTimeMargin is how many times longer than its input could need round-robin may run
+// before it gives up.
+const rrTimeMargin = 4
+
+// rrTimeLimit returns a time round-robin can only pass if it has stopped making progress:
+// a wide margin over the latest arrival plus the dispatcher's start-up, every tick of work
+// with a wake-up and the worst setup before each, and the suspensions. A suspension counts
+// for no more than the rest put together, so one lasting far longer is treated as never
+// ending.
+func rrTimeLimit(processes []Process, opts Options) int64 {
+	var setup, wake int64
+	if opts.Setup.Default > 0 {
+		setup = opts.Setup.Default
+	}
+	if opts.PowerDown.Wake > 0 {
+		wake = opts.PowerDown.Wake
+	}
+	for _, to := range opts.Setup.Matrix {
+		for _, t := range to {
+			if t > setup {
+				setup = t
+			}
+		}
+	}
+	var base int64
+	for i := range processes {
+		if processes[i].ArrivalTime > base {
+			base = processes[i].ArrivalTime
+		}
+	}
+	base += opts.DispatchLatency
+	for i := range processes {
+		base += processes[i].cpuTime() * (1 + wake + setup)
+	}
+
+	limit := base
+	for _, s := range opts.Suspensions {
+		if span := s.To - s.From; span > 0 {
+			if span > base {
+				span = base
+			}
+			limit += span
+		}
+	}
+
+	return rrTimeMargin * limit
+}
+
+// arrivalOrder returns the indices of processes sorted by arrival time, breaking ties by
+// PID, so the order they are listed in makes no difference.
+func arrivalOrder(processes []Process) []int {
+	order := make([]int, len(processes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		pa, pb := processes[order[a]], processes[order[b]]
+		if pa.ArrivalTime != pb.ArrivalTime {
+			return pa.ArrivalTime < pb.ArrivalTime
+		}
+		return pa.ProcessID < pb.ProcessID
+	})
+
+	return order
+}
+
+// dispatchStart returns the earliest time any process can be dispatched: the first
+// arrival, delayed by the dispatcher's start-up latency.
+func dispatchStart(processes []Process, latency int64) int64 {
+	first := processes[0].ArrivalTime
+	for i := range processes {
+		if processes[i].ArrivalTime < first {
+			first = processes[i].ArrivalTime
+		}
+	}
+
+	return first + latency
+}
+
+// runTick records the process with the given ID running from time to time+1,
+// extending its current slice if it was already running.
+func runTick(gantt []TimeSlice, pid, time int64) []TimeSlice {
+	if !isDispatch(gantt, pid, time) {
+		gantt[len(gantt)-1].Stop++
+		return gantt
+	}
+
+	return append(gantt, TimeSlice{PID: pid, Start: time, Stop: time + 1})
+}
+
+// isDispatch reports whether running pid at time starts a new slice rather than
+// continuing the one that just ran.
+func isDispatch(gantt []TimeSlice, pid, time int64) bool {
+	n := len(gantt)
+	return n == 0 || gantt[n-1].PID != pid || gantt[n-1].Stop != time
+}
+
+// readyLabels labels every process that has arrived by time and has work remaining.
+func readyLabels(processes []Process, remaining []int64, time int64, label func(i int) string) []string {
+	var labels []string
+	for i := range processes {
+		if processes[i].ArrivalTime <= time && remaining[i] > 0 {
+			labels = append(labels, label(i))
+		}
+	}
+
+	return labels
+}
+
+// explainDispatch writes why the process with the given ID was dispatched at time.
+func explainDispatch(w io.Writer, time, pid int64, reason string, candidates []string) {
+	_, _ = fmt.Fprintf(w, "t=%d: chose P%d: %s among {%s}\n", time, pid, reason, strings.Join(candidates, ","))
+}
+
+//endregion
+
+//region Metrics
+
+// AverageWait returns the mean waiting time across all processes.
+func (r Result) AverageWait() float64 {
+	return average(r.Wait)
+}
+
+// WaitStddev returns the standard deviation of the wait times; the lower it is, the more
+// evenly the waiting was shared.
+func (r Result) WaitStddev() float64 {
+	return stddev(r.Wait)
+}
+
+// AverageTurnaround returns the mean turnaround time across all processes.
+func (r Result) AverageTurnaround() float64 {
+	return average(r.Turnaround)
+}
+
+// ResponseTimes returns how long each process waited from arrival until it first ran.
+// Only slices ending after the arrival count, so an instance of a periodic task released
+// once the one before it has finished is matched with its own first slice.
+func (r Result) ResponseTimes() []int64 {
+	response := make([]int64, len(r.Processes))
+	for i := range r.Processes {
+		for _, slice := range r.Gantt {
+			if slice.PID == r.Processes[i].ProcessID && slice.Stop > r.Processes[i].ArrivalTime {
+				response[i] = slice.Start - r.Processes[i].ArrivalTime
+				break
+			}
+		}
+	}
+
+	return response
+}
+
+// AverageResponse returns the mean response time across all processes.
+func (r Result) AverageResponse() float64 {
+	return average(r.ResponseTimes())
+}
+
+// ResponseRatios returns, for each process, the wait before its first dispatch plus its
+// burst, divided by its burst: the ratio HRRN weighs when dispatching it. It is 1 if the
+// process started as it arrived, and the larger the longer it waited for its size, so small
+// jobs stuck behind long ones stand out. Unlike the normalized turnaround it leaves out
+// waits after a preemption.
+func (r Result) ResponseRatios() []float64 {
+	response := r.ResponseTimes()
+	ratio := make([]float64, len(r.Processes))
+	for i, p := range r.Processes {
+		ratio[i] = 1
+		if p.cpuTime() > 0 {
+			ratio[i] = float64(response[i]+p.cpuTime()) / float64(p.cpuTime())
+		}
+	}
+
+	return ratio
+}
+
+// NormalizedTurnarounds returns, for each process, its turnaround divided by its burst.
+func (r Result) NormalizedTurnarounds() []float64 {
+	normalized := make([]float64, len(r.Processes))
+	for i, p := range r.Processes {
+		normalized[i] = 1
+		if p.cpuTime() > 0 {
+			normalized[i] = float64(r.Turnaround[i]) / float64(p.cpuTime())
+		}
+	}
+
+	return normalized
+}
+
+// Stretches returns, for each process, the span from its first dispatch to its completion
+// divided by its burst: 1 if it ran without a break, and more the longer it was held off
+// the CPU once started.
+func (r Result) Stretches() []float64 {
+	stretch := make([]float64, len(r.Processes))
+	for i, p := range r.Processes {
+		stretch[i] = 1
+		if start := firstStart(r.Gantt, p.ProcessID); start >= 0 && p.cpuTime() > 0 {
+			stretch[i] = float64(r.Completion[i]-start) / float64(p.cpuTime())
+		}
+	}
+
+	return stretch
+}
+
+// AverageStretch returns the mean stretch across all processes.
+func (r Result) AverageStretch() float64 {
+	if len(r.Processes) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range r.Stretches() {
+		sum += s
+	}
+
+	return sum / float64(len(r.Processes))
+}
+
+// Utilization returns the fraction of the makespan the CPUs spent running processes.
+func (r Result) Utilization() float64 {
+	span := Makespan(r.Processes, r.Gantt)
+	if span == 0 {
+		return 0
+	}
+	var busy int64
+	for _, slice := range r.Gantt {
+		busy += slice.Stop - slice.Start
+	}
+
+	return float64(busy) / float64(span*int64(r.cpus()))
+}
+
+// cpus returns how many CPUs the processes were scheduled on.
+func (r Result) cpus() int {
+	if len(r.Cores) > 1 {
+		return len(r.Cores)
+	}
+
+	return 1
+}
+
+// ContextSwitches returns how many times a CPU moved from one process to another.
+func (r Result) ContextSwitches() int {
+	if len(r.Cores) > 1 {
+		var switches int
+		for _, slices := range r.Cores {
+			switches += Result{Gantt: slices}.ContextSwitches()
+		}
+		return switches
+	}
+	var switches int
+	for i := 1; i < len(r.Gantt); i++ {
+		if r.Gantt[i].PID != r.Gantt[i-1].PID {
+			switches++
+		}
+	}
+
+	return switches
+}
+
+// Preemptions returns how many times a process was taken off the CPU before it completed.
+func (r Result) Preemptions() int {
+	completions := make(map[int64]int64, len(r.Processes))
+	for i := range r.Processes {
+		completions[r.Processes[i].ProcessID] = r.Completion[i]
+	}
+	var preemptions int
+	for _, slice := range r.Gantt {
+		if slice.Stop < completions[slice.PID] {
+			preemptions++
+		}
+	}
+
+	return preemptions
+}
+
+func average(values []int64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return float64(total(values)) / float64(len(values))
+}
+
+// stddev returns the population standard deviation of values, or 0 if there are none.
+func stddev(values []int64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	mean := average(values)
+	var squares float64
+	for _, v := range values {
+		squares += (float64(v) - mean) * (float64(v) - mean)
+	}
+
+	return math.Sqrt(squares / float64(len(values)))
+}
+
+func total(values []int64) int64 {
+	var sum int64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum
+}
+
+// littlesLaw returns both sides of Little's law (L = λW) for a result, from the first
+// arrival until the last process completes:
+// • L, the number of processes in the system averaged tick by tick over the Gantt chart
+// • λ, the arrival rate, multiplied by W, the average of the reported turnarounds
+// The two are measured independently, so a disagreement points at turnarounds that do
+// not match the schedule.
+func littlesLaw(res Result) (inSystem, rateTimesTurnaround float64) {
+	var last int64
+	for i := range res.Processes {
+		if res.Completion[i] > last {
+			last = res.Completion[i]
+		}
+	}
+
+	return littlesLawUntil(res, last)
+}
+
+// littlesLawUntil is littlesLaw over the window from the first arrival up to until. λ
+// counts the arrivals in the window and W averages the turnarounds of the processes that
+// completed in it, so the two sides only agree once every process has left the system.
+func littlesLawUntil(res Result, until int64) (inSystem, rateTimesTurnaround float64) {
+	if len(res.Processes) == 0 {
+		return 0, 0
+	}
+	first := res.Processes[0].ArrivalTime
+	for _, p := range res.Processes {
+		if p.ArrivalTime < first {
+			first = p.ArrivalTime
+		}
+	}
+	departures := ganttDepartures(res)
+	if until <= first {
+		return 0, 0
+	}
+
+	var area int64
+	for t := first; t < until; t++ {
+		for i, p := range res.Processes {
+			if p.ArrivalTime <= t && departures[i] > t {
+				area++
+			}
+		}
+	}
+	var (
+		arrived   int
+		completed []int64
+	)
+	for i, p := range res.Processes {
+		if p.ArrivalTime < until {
+			arrived++
+		}
+		if res.Completion[i] <= until {
+			completed = append(completed, res.Turnaround[i])
+		}
+	}
+	span := float64(until - first)
+
+	return float64(area) / span, float64(arrived) / span * average(completed)
+}
+
+// ganttDepartures returns when each process leaves the system going by the Gantt chart
+// alone: the end of the tick that gives it the last of its CPU time, the instances of a
+// periodic PID taking their ticks in order of arrival.
+func ganttDepartures(res Result) []int64 {
+	var (
+		order      = arrivalOrder(res.Processes)
+		left       = make([]int64, len(res.Processes))
+		departures = make([]int64, len(res.Processes))
+	)
+	for i, p := range res.Processes {
+		left[i] = p.cpuTime()
+		departures[i] = p.ArrivalTime
+	}
+	for _, slice := range res.Gantt {
+		for t := slice.Start; t < slice.Stop; t++ {
+			for _, i := range order {
+				if p := res.Processes[i]; p.ProcessID == slice.PID && p.ArrivalTime <= t && left[i] > 0 {
+					left[i]--
+					departures[i] = t + 1
+					break
+				}
+			}
+		}
+	}
+
+	return departures
+}
+
+// MissedDeadline reports whether the i-th process has a deadline and completed after it.
+func (r Result) MissedDeadline(i int) bool {
+	return r.Processes[i].Deadline > 0 && r.Completion[i] > r.Processes[i].Deadline
+}
+
+// Makespan returns the time from the earliest arrival until the last slice of work stops,
+// the span every algorithm's throughput is measured over.
+func Makespan(processes []Process, gantt []TimeSlice) int64 {
+	if len(processes) == 0 || len(gantt) == 0 {
+		return 0
+	}
+	first := processes[0].ArrivalTime
+	for i := range processes {
+		if processes[i].ArrivalTime < first {
+			first = processes[i].ArrivalTime
+		}
+	}
+	var last int64
+	for i := range gantt {
+		if gantt[i].Stop > last {
+			last = gantt[i].Stop
+		}
+	}
+
+	return last - first
+}
+
+// throughput returns the processes completed per unit of time, measured over the makespan
+// so that a late first arrival doesn't count as time the schedule spent working.
+func throughput(processes []Process, gantt []TimeSlice) float64 {
+	span := Makespan(processes, gantt)
+	if span == 0 {
+		return 0
+	}
+
+	return float64(len(processes)) / float64(span)
+}
+
+// makespanLowerBound returns the shortest makespan any schedule could achieve on the given
+// number of CPUs: the larger of the longest single burst and the total burst spread evenly.
+func makespanLowerBound(processes []Process, cpus int64) int64 {
+	if cpus < 1 {
+		cpus = 1
+	}
+	var longest, total int64
+	for i := range processes {
+		total += processes[i].cpuTime()
+		if processes[i].cpuTime() > longest {
+			longest = processes[i].cpuTime()
+		}
+	}
+	spread := (total + cpus - 1) / cpus
+	if spread > longest {
+		return spread
+	}
+
+	return longest
+}
+
+//endregion
+
+//region Output helpers
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+func outputResult(w io.Writer, title string, res Result, cfg config) {
+	var (
+		rows   = make([][]string, len(res.Processes))
+		missed = make([]bool, len(res.Processes))
+		red    = make(map[int64]bool)
+	)
+	response, ratios, normalized := res.ResponseTimes(), res.ResponseRatios(), res.NormalizedTurnarounds()
+	for i := range res.Processes {
+		if cfg.color && res.MissedDeadline(i) {
+			missed[i] = true
+			red[res.Processes[i].ProcessID] = true
+		}
+		rows[i] = []string{
+			fmt.Sprint(res.Processes[i].ProcessID),
+			fmt.Sprint(res.Processes[i].Priority),
+			fmt.Sprint(res.Processes[i].BurstDuration),
+			fmt.Sprint(res.Processes[i].ArrivalTime),
+			fmt.Sprint(res.Wait[i]),
+			fmt.Sprint(res.Turnaround[i]),
+			fmt.Sprint(response[i]),
+			fmt.Sprintf("%.2f", ratios[i]),
+			fmt.Sprintf("%.2f", normalized[i]),
+			fmt.Sprint(res.Completion[i]),
+		}
+	}
+
+	if cfg.format == "markdown" {
+		outputMarkdownTitle(w, title)
+	} else {
+		outputTitle(w, title)
+	}
+	switch {
+	case len(res.Cores) > 1 && len(res.Gantt) > 0:
+		outputCoresGantt(w, res.Cores)
+	case cfg.ganttFormat == "svg":
+		writeGanttSVG(w, res.Gantt)
+		_, _ = fmt.Fprintln(w)
+	case cfg.format == "markdown":
+		outputMarkdownGantt(w, res.Gantt)
+	case cfg.tabularGantt:
+		outputGanttTable(w, res.Gantt, cfg.reverseGantt)
+	default:
+		outputGantt(w, res.Gantt, red, cfg)
+	}
+	if cfg.format == "markdown" {
+		outputMarkdownSchedule(w, rows, scheduleFooter(res, cfg.showTotals))
+	} else {
+		outputSchedule(w, rows, missed, scheduleFooter(res, cfg.showTotals))
+	}
+	outputMissedDeadlines(w, res)
+	if cfg.bounds {
+		outputTurnaroundBounds(w, res)
+	}
+	outputMakespan(w, res, int64(res.cpus()))
+	if cfg.details {
+		outputWaitStddev(w, res)
+		outputLittlesLaw(w, res)
+		outputUtilization(w, res)
+		outputContextSwitches(w, res)
+		outputPeakBacklog(w, res)
+		outputStretch(w, res)
+		outputOrderPreservation(w, res)
+	}
+	if len(res.Shares) > 0 {
+		outputShares(w, res.Shares)
+	}
+	if len(res.Wins) > 0 {
+		outputWins(w, res)
+	}
+	outputConvoys(w, res.Convoys)
+	outputQuotaExceeded(w, res)
+	if res.SameAsFCFS {
+		outputSameAsFCFS(w)
+	}
+	if cfg.waitHistogram {
+		outputWaitHistogram(w, res)
+	}
+	if cfg.intervals {
+		outputIntervals(w, res)
+	}
+}
+
+// outputAll writes every result, or only the snapshot or winners if cfg asks for them.
+func outputAll(w io.Writer, results []Result, cfg config) {
+	if cfg.snapshot {
+		outputSnapshot(w, results)
+		return
+	}
+	if cfg.winners {
+		outputWinners(w, results)
+		return
+	}
+	for _, res := range results {
+		outputResult(w, res.Title, res, cfg)
+	}
+	if cfg.stackedGantt {
+		outputStackedGantt(w, results)
+	}
+	if cfg.comparison {
+		outputComparison(w, results)
+	}
+	if cfg.groupedComparison {
+		outputGroupedComparison(w, results)
+	}
+}
+
+// outputNeverArrived reports processes left out because they arrive after the horizon.
+func outputNeverArrived(w io.Writer, never int, horizon int64) {
+	noun := "processes"
+	if never == 1 {
+		noun = "process"
+	}
+	_, _ = fmt.Fprintf(w, "%d %s never arrived within horizon %d\n", never, noun, horizon)
+}
+
+func outputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
+
+// outputGantt writes the Gantt chart under a heading, drawn as ganttChart does.
+func outputGantt(w io.Writer, gantt []TimeSlice, red map[int64]bool, cfg config) {
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	_, _ = fmt.Fprintln(w, ganttChart(gantt, red, cfg))
+}
+
+// ganttString returns the Gantt chart as text, with its time axis, drawn as it is by default.
+func ganttString(gantt []TimeSlice) string {
+	return ganttChart(gantt, nil, config{})
+}
+
+// ganttChart draws the Gantt chart over its time axis, coloring the slices of any PID set in
+// red, and with cfg.color every other slice in its process's color. With cfg.reverseGantt
+// the chart is drawn from the end backward, with the axis counting down, and with
+// cfg.cumulativeAxis a second axis gives the CPU busy time at each boundary.
+func ganttChart(gantt []TimeSlice, red map[int64]bool, cfg config) string {
+	drawn := withIdleSlices(gantt)
+	if cfg.reverseGantt {
+		drawn = reversedGantt(drawn)
+	}
+	widths := make([]int, len(drawn))
+	var b strings.Builder
+	b.WriteString("|")
+	for i := range drawn {
+		label := ganttLabel(drawn[i].PID)
+		widths[i] = ganttCellWidth(drawn[i], label, cfg.reverseGantt)
+		left := (widths[i] - len(label)) / 2
+		right := widths[i] - len(label) - left
+		switch {
+		case red[drawn[i].PID]:
+			label = ansiRed + label + ansiReset
+		case cfg.color:
+			label = colorForPID(drawn[i].PID) + label + ansiReset
+		}
+		_, _ = fmt.Fprint(&b, strings.Repeat(" ", left), label, strings.Repeat(" ", right), "|")
+	}
+	b.WriteString("\n")
+	outputGanttAxis(&b, drawn, widths, cfg.reverseGantt, func(t int64) int64 { return t })
+	if cfg.cumulativeAxis {
+		b.WriteString("\n")
+		outputGanttAxis(&b, drawn, widths, cfg.reverseGantt, func(t int64) int64 { return busyUntil(gantt, t) })
+		b.WriteString(" (busy)")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// maxGanttCell is the widest a Gantt chart cell grows, however long its slice.
+const maxGanttCell = 20
+
+// withIdleSlices returns a copy of gantt with a slice for idlePID filling each gap
+// between one slice stopping and the next starting.
+func withIdleSlices(gantt []TimeSlice) []TimeSlice {
+	out := make([]TimeSlice, 0, len(gantt))
+	for i := range gantt {
+		if i > 0 && gantt[i].Start > gantt[i-1].Stop {
+			out = append(out, TimeSlice{PID: idlePID, Start: gantt[i-1].Stop, Stop: gantt[i].Start})
+		}
+		out = append(out, gantt[i])
+	}
+
+	return out
+}
+
+// ganttLabel returns what a Gantt chart cell shows for the process with the given ID.
+func ganttLabel(pid int64) string {
+	if pid == idlePID {
+		return "idle"
+	}
+
+	return fmt.Sprint(pid)
+}
+
+// ganttCellWidth returns the width of a slice's cell between its bars: a character per
+// tick up to maxGanttCell, but always room for the label with a space either side and for
+// the time mark under the cell's first bar with a space after it.
+func ganttCellWidth(slice TimeSlice, label string, reverse bool) int {
+	first := slice.Start
+	if reverse {
+		first = slice.Stop
+	}
+	width := int(slice.Stop - slice.Start)
+	if width > maxGanttCell {
+		width = maxGanttCell
+	}
+	if n := len(label) + 2; n > width {
+		width = n
+	}
+	if n := len(fmt.Sprint(first)) + 1; n > width {
+		width = n
+	}
+
+	return width
+}
+
+// outputGanttAxis writes the boundary of each drawn slice, as mapped by mark, under the bar
+// it falls on for cells of the given widths, without ending the line. Reversed slices are
+// bounded by their stop first.
+func outputGanttAxis(w io.Writer, drawn []TimeSlice, widths []int, reverse bool, mark func(t int64) int64) {
+	for i := range drawn {
+		start, stop := drawn[i].Start, drawn[i].Stop
+		if reverse {
+			start, stop = stop, start
+		}
+		_, _ = fmt.Fprintf(w, "%-*d", widths[i]+1, mark(start))
+		if len(drawn)-1 == i {
+			_, _ = fmt.Fprint(w, mark(stop))
+		}
+	}
+}
+
+// busyUntil returns how long the CPU spent running processes before time.
+func busyUntil(gantt []TimeSlice, time int64) int64 {
+	var busy int64
+	for _, slice := range gantt {
+		switch {
+		case slice.Stop <= time:
+			busy += slice.Stop - slice.Start
+		case slice.Start < time:
+			busy += time - slice.Start
+		}
+	}
+
+	return busy
+}
+
+func outputGanttTable(w io.Writer, gantt []TimeSlice, reverse bool) {
+	if reverse {
+		gantt = reversedGantt(gantt)
+	}
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"PID", "Start", "Stop", "Duration"})
+	for i := range gantt {
+		table.Append([]string{
+			fmt.Sprint(gantt[i].PID),
+			fmt.Sprint(gantt[i].Start),
+			fmt.Sprint(gantt[i].Stop),
+			fmt.Sprint(gantt[i].Stop - gantt[i].Start),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
+
+// reversedGantt returns a copy of gantt with the slices in reverse order.
+func reversedGantt(gantt []TimeSlice) []TimeSlice {
+	out := make([]TimeSlice, len(gantt))
+	for i := range gantt {
+		out[len(gantt)-1-i] = gantt[i]
+	}
+
+	return out
+}
+
+func outputLittlesLaw(w io.Writer, res Result) {
+	inSystem, rateTimesTurnaround := littlesLaw(res)
+	_, _ = fmt.Fprintf(w, "Little's law: L = %.2f, λW = %.2f\n", inSystem, rateTimesTurnaround)
+}
+
+// outputStretch writes the average stretch, how drawn out execution was by preemption.
+func outputStretch(w io.Writer, res Result) {
+	_, _ = fmt.Fprintf(w, "Average stretch: %.2f\n", res.AverageStretch())
+}
+
+// outputWaitStddev writes the standard deviation of the wait times.
+func outputWaitStddev(w io.Writer, res Result) {
+	_, _ = fmt.Fprintf(w, "Wait stddev: %.2f\n", res.WaitStddev())
+}
+
+// outputMakespan writes the makespan and, on several CPUs, the least it could be.
+func outputMakespan(w io.Writer, res Result, cpus int64) {
+	if cpus == 1 {
+		_, _ = fmt.Fprintf(w, "Makespan: %d\n", Makespan(res.Processes, res.Gantt))
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Makespan: %d (lower bound %d on %d CPUs)\n",
+		Makespan(res.Processes, res.Gantt), makespanLowerBound(res.Processes, cpus), cpus)
+}
+
+// outputContextSwitches writes how many times the CPU moved from one process to another.
+func outputContextSwitches(w io.Writer, res Result) {
+	_, _ = fmt.Fprintf(w, "Context switches: %d\n", res.ContextSwitches())
+}
+
+// scheduleFooter returns the footer of the schedule table: the average wait and
+// turnaround, with their totals if asked for, and the throughput.
+func scheduleFooter(res Result, showTotals bool) []string {
+	wait := fmt.Sprintf("Average\n%.2f", res.AverageWait())
+	turnaround := fmt.Sprintf("Average\n%.2f", res.AverageTurnaround())
+	if showTotals {
+		wait += fmt.Sprintf("\nTotal\n%d", total(res.Wait))
+		turnaround += fmt.Sprintf("\nTotal\n%d", total(res.Turnaround))
+	}
+
+	response := fmt.Sprintf("Average\n%.2f", res.AverageResponse())
+	ratio := fmt.Sprintf("Average\n%.2f", mean(res.ResponseRatios()))
+	normalized := fmt.Sprintf("Average\n%.2f", mean(res.NormalizedTurnarounds()))
+
+	return []string{"", "", "", "", wait, turnaround, response, ratio, normalized,
+		fmt.Sprintf("Throughput\n%.2f/t", res.Throughput)}
+}
+
+// scheduleColumns heads the columns of the schedule table.
+var scheduleColumns = []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Response",
+	"Response ratio", "Normalized turnaround", "Exit"}
+
+// outputSchedule writes the schedule table, coloring any row flagged in red.
+func outputSchedule(w io.Writer, rows [][]string, red []bool, footer []string) {
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(scheduleColumns)
+	for i := range rows {
+		if red[i] {
+			// escape codes stop tablewriter recognising numbers, so align explicitly
+			table.SetAlignment(tablewriter.ALIGN_RIGHT)
+			colors := make([]tablewriter.Colors, len(rows[i]))
+			for j := range colors {
+				colors[j] = tablewriter.Colors{tablewriter.FgRedColor}
+			}
+			table.Rich(rows[i], colors)
+			continue
+		}
+		table.Append(rows[i])
+	}
+	table.SetFooter(footer)
+	table.Render()
+}
+
+//endregion
+
+//region Loading processes.
+
+var (
+	ErrInvalidArgs = errors.New("invalid args")
+	ErrUnsorted    = errors.New("processes not sorted by arrival time")
+	// ErrSchedulerPanic reports a scheduler that panicked instead of returning a result.
+	ErrSchedulerPanic = errors.New("scheduler panicked")
+	// ErrNoProgress reports a scheduler that ran far past any time its input could need.
+	ErrNoProgress = errors.New("scheduler made no progress")
+)
+
+func LoadProcesses(r io.Reader) ([]Process, error) {
+	rows, first, err := readProcessRows(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return processesFromRows(rows, first)
+}
+
+// readProcessRows reads the CSV rows of processes, returning them with the number of the
+// first in the file.
+func readProcessRows(r io.Reader) ([][]string, int, error) {
+	// rows may leave off trailing columns, so their lengths are checked one by one
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: reading CSV", err)
+	}
+	// spreadsheets export a header line naming the columns
+	first := 1
+	if len(rows) > 0 && isHeaderRow(rows[0]) {
+		rows = rows[1:]
+		first++
+	}
+
+	return rows, first, nil
+}
+
+// validateProcesses rejects processes the schedulers cannot handle: a negative arrival,
+// a burst that is not positive, or a PID used more than once.
+func validateProcesses(processes []Process) error {
+	seen := make(map[int64]bool, len(processes))
+	for _, p := range processes {
+		if p.BurstDuration <= 0 {
+			return fmt.Errorf("%w: process %d has burst %d, want > 0", ErrInvalidArgs, p.ProcessID, p.BurstDuration)
+		}
+		if p.ArrivalTime < 0 {
+			return fmt.Errorf("%w: process %d has arrival %d, want >= 0", ErrInvalidArgs, p.ProcessID, p.ArrivalTime)
+		}
+		if seen[p.ProcessID] {
+			return fmt.Errorf("%w: process %d is listed more than once", ErrInvalidArgs, p.ProcessID)
+		}
+		seen[p.ProcessID] = true
+	}
+
+	return nil
+}
+
+// isHeaderRow reports whether row names columns rather than holding a process: none of
+// its fields is a whole number.
+func isHeaderRow(row []string) bool {
+	for _, field := range row {
+		if _, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64); err == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// processesFromRows parses rows laid out in the CSV column order: pid, burst, arrival
+// and optionally priority, deadline, class, weight, rate and quota. firstRow is the
+// number of the first row in its file, for errors.
+func processesFromRows(rows [][]string, firstRow int) ([]Process, error) {
+	processes := make([]Process, len(rows))
+	for i := range rows {
+		if !processFieldCount(len(rows[i])) {
+			return nil, fmt.Errorf("%w: row %d has %d fields, want %d to %d, or %d plus %d per result in an annotated file",
+				ErrInvalidArgs, firstRow+i, len(rows[i]), requiredColumns, processColumns, processColumns, annotatedColumns)
+		}
+		p := &processes[i]
+		// every column but the class is a whole number
+		columns := []*int64{&p.ProcessID, &p.BurstDuration, &p.ArrivalTime, &p.Priority, &p.Deadline, nil, &p.Weight, &p.Rate, &p.Quota, &p.Period, &p.Tickets}
+		for j := 0; j < len(rows[i]) && j < len(columns); j++ {
+			if columns[j] == nil {
+				p.Class = strings.TrimSpace(rows[i][j])
+				continue
+			}
+			v, err := strconv.ParseInt(strings.TrimSpace(rows[i][j]), 10, 64)
+			if err != nil {
+				return nil, &FieldError{Row: firstRow + i, Col: j + 1, Err: err}
+			}
+			*columns[j] = v
+		}
+	}
+
+	return processes, nil
+}
+
+// FieldError reports a field of a scheduling file that is not a whole number. It is an
+// ErrInvalidArgs, and unwraps to the error parsing the field.
+type FieldError struct {
+	Row, Col int
+	Err      error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%v: row %d col %d: %v", ErrInvalidArgs, e.Row, e.Col, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidArgs.
+func (e *FieldError) Is(target error) bool {
+	return target == ErrInvalidArgs
+}
+
+// readIntRows reads CSV rows of whole numbers laid out as format, such as "pid,time",
+// naming each row after what in errors.
+func readIntRows(r io.Reader, what, format string) ([][]int64, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	fields := len(strings.Split(format, ","))
+	out := make([][]int64, len(rows))
+	for i := range rows {
+		if len(rows[i]) != fields {
+			return nil, fmt.Errorf("%w: %s row %d must be %s", ErrInvalidArgs, what, i+1, format)
+		}
+		out[i] = make([]int64, fields)
+		for j := range rows[i] {
+			if out[i][j], err = strconv.ParseInt(strings.TrimSpace(rows[i][j]), 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: %s row %d: %v", ErrInvalidArgs, what, i+1, err)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// processFields returns the process as a row of every column LoadProcesses reads.
+func processFields(p Process) []string {
+	return []string{
+		strconv.FormatInt(p.ProcessID, 10),
+		strconv.FormatInt(p.BurstDuration, 10),
+		strconv.FormatInt(p.ArrivalTime, 10),
+		strconv.FormatInt(p.Priority, 10),
+		strconv.FormatInt(p.Deadline, 10),
+		p.Class,
+		strconv.FormatInt(p.Weight, 10),
+		strconv.FormatInt(p.Rate, 10),
+		strconv.FormatInt(p.Quota, 10),
+		strconv.FormatInt(p.Period, 10),
+		strconv.FormatInt(p.Tickets, 10),
+	}
+}
+
+// checkSorted returns ErrUnsorted if arrival times decrease anywhere in input order.
+func checkSorted(processes []Process) error {
+	for i := 1; i < len(processes); i++ {
+		if processes[i].ArrivalTime < processes[i-1].ArrivalTime {
+			return fmt.Errorf("%w: process %d (arrival %d) listed after process %d (arrival %d)", ErrUnsorted,
+				processes[i].ProcessID, processes[i].ArrivalTime,
+				processes[i-1].ProcessID, processes[i-1].ArrivalTime)
+		}
+	}
+
+	return nil
+}
+
+// withinHorizon returns the processes arriving no later than horizon, in their original
+// order, and how many arrive after it and so never get scheduled.
+func withinHorizon(processes []Process, horizon int64) ([]Process, int) {
+	arrived := make([]Process, 0, len(processes))
+	for i := range processes {
+		if processes[i].ArrivalTime <= horizon {
+			arrived = append(arrived, processes[i])
+		}
+	}
+
+	return arrived, len(processes) - len(arrived)
+}
+
+//endregion
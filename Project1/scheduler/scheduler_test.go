@@ -0,0 +1,2046 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestFCFSSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 5,
+						Priority:      2,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   3,
+						BurstDuration: 9,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   6,
+						BurstDuration: 6,
+						Priority:      3,
+					},
+				},
+				title: "First-come, First-serve",
+			},
+			wantOut: loadFixture(t, "fcfs_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			FCFSSchedule(&w, tt.args.title, tt.args.processes)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("FCFSSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_loadProcesses(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "bad CSV",
+			args: args{
+				r: iotest.ErrReader(io.ErrUnexpectedEOF),
+			},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "success",
+			args: args{
+				r: strings.NewReader(`1,5,0,2
+2,9,3,1
+3,6,3,3`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Priority:      3,
+				},
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				r: strings.NewReader(""),
+			},
+			want: []Process{},
+		},
+		{
+			name: "bad burst",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,soon,3,1\n"),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "short row",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,9\n"),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "too long row",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,9,3,1,0,A,1,1,0,0,1,7\n"),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			// every process column and then a result's wait, turnaround and completion,
+			// which are ignored
+			name: "annotated row",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,9,3,1,0,A,1,1,0,0,1,2,11,14\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1, Class: "A", Weight: 1, Rate: 1, Tickets: 1},
+			},
+		},
+		{
+			name: "annotated row missing a result column",
+			args: args{
+				r: strings.NewReader("1,5,0,2,0,,0,0,0,0,0,0,5\n"),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "header row",
+			args: args{
+				r: strings.NewReader(`ProcessID,BurstDuration,ArrivalTime,Priority
+1,5,0,2
+2,9,3,1
+3,6,3,3`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Priority:      3,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := LoadProcesses(tt.args.r)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProcesses() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// mustSchedule schedules the processes, failing the test if the scheduler cannot.
+func mustSchedule(t *testing.T, schedule scheduleFunc, processes []Process, opts Options) Result {
+	t.Helper()
+	res, err := schedule(processes, opts)
+	if err != nil {
+		t.Fatalf("schedule() error = %v", err)
+	}
+
+	return res
+}
+
+// loadTestdata loads the processes in the named CSV file in testdata, failing the test if
+// it cannot.
+func loadTestdata(t *testing.T, name string) []Process {
+	t.Helper()
+	f, err := os.Open(path.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	processes, err := LoadProcesses(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return processes
+}
+
+func loadFixture(t *testing.T, p ...string) string {
+	b, err := os.ReadFile(path.Join(p...))
+	if err != nil {
+		t.Fail()
+	}
+
+	return string(b)
+}
+
+func Test_parseArgs_negative(t *testing.T) {
+	t.Parallel()
+	for _, flag := range []string{"-setup-time", "-dispatch-latency", "-power-down-after", "-wake-penalty"} {
+		flag := flag
+		t.Run(flag, func(t *testing.T) {
+			t.Parallel()
+			if _, _, err := parseArgs("binary_name", flag+"=-1", "../example_processes.csv"); !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("parseArgs(%s=-1) error = %v, want %v", flag, err, ErrInvalidArgs)
+			}
+			cfg, _, err := parseArgs("binary_name", flag+"=0", "../example_processes.csv")
+			if err != nil {
+				t.Fatalf("parseArgs(%s=0) error = %v", flag, err)
+			}
+			if got := cfg.flags[flag[1:]]; got != "0" {
+				t.Errorf("flags[%q] = %q, want %q", flag[1:], got, "0")
+			}
+		})
+	}
+}
+
+func Test_openProcessingFile1(t *testing.T) {
+	tmpFile, tErr := os.CreateTemp(t.TempDir(), "")
+	if tErr != nil {
+		t.Fatal(tErr)
+	}
+	otherFile, tErr := os.CreateTemp(t.TempDir(), "")
+	if tErr != nil {
+		t.Fatal(tErr)
+	}
+
+	type args struct {
+		args []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*os.File
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				args: []string{"binary_name", tmpFile.Name()},
+			},
+			want: []*os.File{tmpFile},
+		},
+		{
+			name: "several files",
+			args: args{
+				args: []string{"binary_name", tmpFile.Name(), otherFile.Name()},
+			},
+			want: []*os.File{tmpFile, otherFile},
+		},
+		{
+			name: "not enough args",
+			args: args{
+				args: []string{"binary_name"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad file",
+			args: args{
+				args: []string{"binary_name", "bad_file_name"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "one bad file among several",
+			args: args{
+				args: []string{"binary_name", tmpFile.Name(), "bad_file_name"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, closeFn, err := openProcessingFile(tt.args.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("openProcessingFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d files, want %d", len(got), len(tt.want))
+			}
+			if closeFn == nil {
+				t.Fatal("closeFn is unexpectedly nil")
+			}
+			t.Cleanup(closeFn)
+
+			for i := range got {
+				f1, err := os.Stat(got[i].Name())
+				if err != nil {
+					t.Fatalf("Could not stat file: %v", got[i])
+				}
+				f2, err := os.Stat(tt.want[i].Name())
+				if err != nil {
+					t.Fatalf("Could not stat file: %v", tt.want[i])
+				}
+
+				if !os.SameFile(f1, f2) {
+					t.Fatal("files are not the same")
+				}
+			}
+		})
+	}
+}
+
+func Test_littlesLaw(t *testing.T) {
+	t.Parallel()
+	// a steady workload: one arrival every 4 ticks, each needing 3 ticks of CPU
+	steady := make([]Process, 20)
+	for i := range steady {
+		steady[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   int64(i * 4),
+			BurstDuration: 3,
+			Priority:      1,
+		}
+	}
+	// a backlogged workload where everything arrives at once
+	backlog := make([]Process, 5)
+	for i := range backlog {
+		backlog[i] = Process{
+			ProcessID:     int64(i + 1),
+			BurstDuration: int64(i + 2),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		processes []Process
+	}{
+		{
+			name:      "steady",
+			processes: steady,
+		},
+		{
+			name:      "backlog",
+			processes: backlog,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			inSystem, rateTimesTurnaround := littlesLaw(FCFS(tt.processes, Options{}))
+			if inSystem <= 0 {
+				t.Fatalf("littlesLaw() L = %v, want > 0", inSystem)
+			}
+			if diff := math.Abs(inSystem - rateTimesTurnaround); diff > 0.01 {
+				t.Errorf("littlesLaw() L = %v, λW = %v, differ by %v", inSystem, rateTimesTurnaround, diff)
+			}
+		})
+	}
+
+	t.Run("before the backlog drains", func(t *testing.T) {
+		t.Parallel()
+		// by time 5, P1 has left at 2 and P2 at 5, so 5 processes were in the system for 2
+		// ticks and 4 for 3 (L = 22/5), while only the turnarounds 2 and 5 are in
+		// (λW = 5/5 * 3.5)
+		inSystem, rateTimesTurnaround := littlesLawUntil(FCFS(backlog, Options{}), 5)
+		if inSystem != 4.4 || rateTimesTurnaround != 3.5 {
+			t.Errorf("littlesLawUntil() L = %v, λW = %v, want 4.4 and 3.5", inSystem, rateTimesTurnaround)
+		}
+	})
+
+	t.Run("turnaround off the schedule", func(t *testing.T) {
+		t.Parallel()
+		res := FCFS(backlog, Options{})
+		res.Turnaround = append([]int64{}, res.Turnaround...)
+		res.Turnaround[0] += 10
+		inSystem, rateTimesTurnaround := littlesLaw(res)
+		if diff := math.Abs(inSystem - rateTimesTurnaround); diff < 0.5 {
+			t.Errorf("littlesLaw() L = %v, λW = %v, want them apart", inSystem, rateTimesTurnaround)
+		}
+	})
+}
+
+func Test_run_output(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	out := path.Join(dir, "out.txt")
+	// a stale file is replaced, not appended to
+	if err := os.WriteFile(out, []byte("stale\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := Run([]string{"binary_name", "../example_processes.csv"}, &stdout); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	var rest bytes.Buffer
+	if err := Run([]string{"binary_name", "-output", out, "../example_processes.csv"}, &rest); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if rest.Len() != 0 {
+		t.Errorf("run() wrote %q to stdout, want nothing", rest.String())
+	}
+	if got := loadFixture(t, out); got != stdout.String() {
+		t.Errorf("output file differs from stdout:\n%s\nwant:\n%s", got, stdout.String())
+	}
+
+	if err := Run([]string{"binary_name", "-output", out, "-append", out, "../example_processes.csv"}, io.Discard); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("run() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_run_append(t *testing.T) {
+	dir := t.TempDir()
+	first := path.Join(dir, "first.csv")
+	second := path.Join(dir, "second.csv")
+	out := path.Join(dir, "out.txt")
+	if err := os.WriteFile(first, []byte("1,5,0,2\n2,9,3,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte("7,4,0,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{first, second} {
+		var stdout bytes.Buffer
+		if err := Run([]string{"binary_name", "-append", out, input}, &stdout); err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("run() wrote %q to stdout, want nothing", stdout.String())
+		}
+	}
+
+	got := loadFixture(t, out)
+	firstRun := strings.Index(got, "Run at ")
+	secondRun := strings.LastIndex(got, "Run at ")
+	if firstRun == secondRun {
+		t.Fatalf("want two run headers, got:\n%s", got)
+	}
+	if !strings.Contains(got[firstRun:secondRun], first) || !strings.Contains(got[firstRun:secondRun], "|  2 |") {
+		t.Errorf("first run missing or out of order:\n%s", got)
+	}
+	if !strings.Contains(got[secondRun:], second) || !strings.Contains(got[secondRun:], "|  7 |") {
+		t.Errorf("second run missing or out of order:\n%s", got)
+	}
+	if strings.Count(got, "First-come, first-serve") != 2 {
+		t.Errorf("want both runs' schedules, got:\n%s", got)
+	}
+}
+
+func Test_checkSorted(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   error
+	}{
+		{
+			name: "sorted",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0},
+				{ProcessID: 2, ArrivalTime: 3},
+				{ProcessID: 3, ArrivalTime: 3},
+			},
+		},
+		{
+			name: "unsorted",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0},
+				{ProcessID: 2, ArrivalTime: 6},
+				{ProcessID: 3, ArrivalTime: 3},
+			},
+			wantErr: ErrUnsorted,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := checkSorted(tt.processes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkSorted() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_run_emptyInput(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	empty := path.Join(dir, "empty.csv")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	single := path.Join(dir, "single.csv")
+	if err := os.WriteFile(single, []byte("1,5,0,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Run([]string{"binary_name", empty}, io.Discard)
+	if !errors.Is(err, ErrInvalidArgs) || !strings.Contains(err.Error(), "no processes to schedule") {
+		t.Errorf("run() error = %v, want no processes to schedule", err)
+	}
+	if err := Run([]string{"binary_name", single}, io.Discard); err != nil {
+		t.Errorf("run() error = %v, want nil", err)
+	}
+}
+
+func Test_schedulers_noProcesses(t *testing.T) {
+	t.Parallel()
+	all := append([]algorithm{}, schedulers...)
+	for _, name := range []string{"wfq", "sjf-np", "sjf-lookahead", "srtf", "hrrn", "ljf", "lrtf", "mlfq", "edf", "rm", "lottery"} {
+		all = append(all, extraSchedulers[name])
+	}
+
+	for _, s := range all {
+		s := s
+		t.Run(s.title, func(t *testing.T) {
+			t.Parallel()
+			got := mustSchedule(t, s.schedule, []Process{}, Options{})
+			if len(got.Wait) != 0 || len(got.Gantt) != 0 {
+				t.Errorf("got waits %v and Gantt %v, want none", got.Wait, got.Gantt)
+			}
+			if got.Throughput != 0 || got.AverageWait() != 0 {
+				t.Errorf("got throughput %v and average wait %v, want 0", got.Throughput, got.AverageWait())
+			}
+		})
+	}
+}
+
+func Test_run_checkSorted(t *testing.T) {
+	dir := t.TempDir()
+	unsorted := path.Join(dir, "unsorted.csv")
+	if err := os.WriteFile(unsorted, []byte("1,5,6,2\n2,9,0,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sorted := path.Join(dir, "sorted.csv")
+	if err := os.WriteFile(sorted, []byte("2,9,0,1\n1,5,6,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Run([]string{"binary_name", "-check-sorted", unsorted}, io.Discard); !errors.Is(err, ErrUnsorted) {
+		t.Errorf("run() error = %v, want %v", err, ErrUnsorted)
+	}
+	if err := Run([]string{"binary_name", "-check-sorted", sorted}, io.Discard); err != nil {
+		t.Errorf("run() error = %v, want nil", err)
+	}
+}
+
+func Test_stddev(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		values []int64
+		want   float64
+	}{
+		{name: "none"},
+		{name: "all equal", values: []int64{4, 4, 4}},
+		{name: "known values", values: []int64{2, 4, 4, 4, 5, 5, 7, 9}, want: 2},
+		{name: "example FCFS waits", values: []int64{0, 2, 8}, want: 3.3993},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := stddev(tt.values); math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("stddev(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_makespanLowerBound(t *testing.T) {
+	t.Parallel()
+	equal := []Process{
+		{ProcessID: 1, BurstDuration: 5},
+		{ProcessID: 2, BurstDuration: 5},
+		{ProcessID: 3, BurstDuration: 5},
+		{ProcessID: 4, BurstDuration: 5},
+	}
+	tests := []struct {
+		name      string
+		processes []Process
+		cpus      int64
+		want      int64
+	}{
+		{
+			name:      "4 equal jobs on 2 CPUs",
+			processes: equal,
+			cpus:      2,
+			want:      2 * 5,
+		},
+		{
+			name:      "4 equal jobs on 1 CPU",
+			processes: equal,
+			cpus:      1,
+			want:      4 * 5,
+		},
+		{
+			name: "longest burst dominates",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 10},
+				{ProcessID: 2, BurstDuration: 1},
+				{ProcessID: 3, BurstDuration: 1},
+			},
+			cpus: 2,
+			want: 10,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := makespanLowerBound(tt.processes, tt.cpus); got != tt.want {
+				t.Errorf("makespanLowerBound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var w bytes.Buffer
+	FCFSSchedule(&w, "First-come, first-serve", equal)
+	if want := "Makespan: 20\n"; !strings.Contains(w.String(), want) {
+		t.Errorf("FCFSSchedule() output missing %q:\n%s", want, w.String())
+	}
+	// on one CPU the bound is the total burst, so it is only given for several
+	if strings.Contains(w.String(), "lower bound") {
+		t.Errorf("FCFSSchedule() output has a lower bound on 1 CPU:\n%s", w.String())
+	}
+}
+
+func Test_outputGanttTable(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 7},
+		{PID: 1, Start: 7, Stop: 10},
+	}
+	var w bytes.Buffer
+	outputGanttTable(&w, gantt, false)
+
+	var rows [][]string
+	for _, line := range strings.Split(w.String(), "\n") {
+		if !strings.HasPrefix(line, "|") || strings.Contains(line, "PID") {
+			continue
+		}
+		rows = append(rows, strings.Fields(strings.ReplaceAll(line, "|", " ")))
+	}
+	want := [][]string{
+		{"1", "0", "2", "2"},
+		{"2", "2", "7", "5"},
+		{"1", "7", "10", "3"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("outputGanttTable() rows = %v, want %v", rows, want)
+	}
+}
+
+func Test_outputGantt_golden(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		golden    string
+	}{
+		{
+			// the long slice's cell is capped, and the seven digit mark still fits
+			name: "long burst",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0},
+				{ProcessID: 12, BurstDuration: 4, ArrivalTime: 2},
+				{ProcessID: 3, BurstDuration: 1234567, ArrivalTime: 3},
+			},
+			golden: "testdata/gantt_fcfs.golden",
+		},
+		{
+			name: "idle gap",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 2, ArrivalTime: 5},
+				{ProcessID: 3, BurstDuration: 4, ArrivalTime: 6},
+			},
+			golden: "testdata/gantt_idle.golden",
+		},
+		{
+			name: "two digit PIDs",
+			processes: []Process{
+				{ProcessID: 10, BurstDuration: 1, ArrivalTime: 0},
+				{ProcessID: 11, BurstDuration: 6, ArrivalTime: 1},
+				{ProcessID: 99, BurstDuration: 2, ArrivalTime: 2},
+			},
+			golden: "testdata/gantt_pids.golden",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGantt(&w, FCFS(tt.processes, Options{}).Gantt, nil, config{})
+			if want := loadFixture(t, tt.golden); w.String() != want {
+				t.Errorf("outputGantt() =\n%s\nwant:\n%s", w.String(), want)
+			}
+
+			// every bar has a mark under it
+			lines := strings.Split(w.String(), "\n")
+			for i, r := range lines[1] {
+				if r == '|' && (i >= len(lines[2]) || lines[2][i] == ' ') {
+					t.Errorf("no mark under the bar at column %d:\n%s", i, w.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_ganttString(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 2, Start: 5, Stop: 7},
+		{PID: 3, Start: 7, Stop: 11},
+	}
+	want := "| 1 | idle | 2 | 3  |\n0   3      5   7    11\n"
+	if got := ganttString(gantt); got != want {
+		t.Errorf("ganttString() =\n%s\nwant:\n%s", got, want)
+	}
+
+	// the writer version only adds the heading and a blank line
+	var w bytes.Buffer
+	outputGantt(&w, gantt, nil, config{})
+	if want := "Gantt schedule\n" + want + "\n"; w.String() != want {
+		t.Errorf("outputGantt() =\n%s\nwant:\n%s", w.String(), want)
+	}
+}
+
+func Test_outputGantt_reverse(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+	}
+	for _, res := range RunAll(processes, Options{}) {
+		res := res
+		t.Run(res.Title, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGantt(&w, res.Gantt, nil, config{reverseGantt: true})
+			lines := strings.Split(w.String(), "\n")
+			labels := strings.Fields(strings.ReplaceAll(lines[1], "|", " "))
+			axis := strings.Fields(lines[2])
+
+			// the first slice drawn belongs to the process that completed last
+			last := 0
+			for i := range res.Completion {
+				if res.Completion[i] > res.Completion[last] {
+					last = i
+				}
+			}
+			if want := fmt.Sprint(res.Processes[last].ProcessID); labels[0] != want {
+				t.Errorf("first slice = P%s, want P%s:\n%s", labels[0], want, w.String())
+			}
+			// the axis counts down from the makespan, one mark per slice boundary
+			if want := fmt.Sprint(res.Completion[last]); axis[0] != want {
+				t.Errorf("axis starts at %s, want %s", axis[0], want)
+			}
+			if axis[len(axis)-1] != "0" || len(axis) != len(labels)+1 {
+				t.Errorf("axis = %q, want %d marks ending at 0", axis, len(labels)+1)
+			}
+		})
+	}
+}
+
+func Test_outputGantt_cumulativeAxis(t *testing.T) {
+	t.Parallel()
+	// the CPU idles from 3 to 5, drawn as a slice of its own, so from then on busy time
+	// lags wall-clock time by 2
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 2, Start: 5, Stop: 7},
+		{PID: 3, Start: 7, Stop: 11},
+	}
+	var w bytes.Buffer
+	outputGantt(&w, gantt, nil, config{cumulativeAxis: true})
+	lines := strings.Split(w.String(), "\n")
+	wall := strings.Fields(lines[2])
+	busy := strings.Fields(strings.TrimSuffix(lines[3], " (busy)"))
+	if len(busy) != len(wall) {
+		t.Fatalf("busy axis %q has %d marks, want %d like %q", busy, len(busy), len(wall), wall)
+	}
+	wantLag := []int64{0, 0, 2, 2, 2}
+	for i := range wall {
+		var wallTime, busyTime int64
+		if _, err := fmt.Sscan(wall[i], &wallTime); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fmt.Sscan(busy[i], &busyTime); err != nil {
+			t.Fatal(err)
+		}
+		if lag := wallTime - busyTime; lag != wantLag[i] {
+			t.Errorf("mark %d: wall %d, busy %d, lag %d, want %d", i, wallTime, busyTime, lag, wantLag[i])
+		}
+	}
+}
+
+func Test_stretches(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 4, ArrivalTime: 0},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 7},
+	}
+	tests := []struct {
+		name string
+		res  Result
+		want []float64
+	}{
+		{
+			name: "run without a break",
+			res: Result{
+				Processes:  processes,
+				Completion: []int64{2, 6, 8},
+				Gantt: []TimeSlice{
+					{PID: 1, Start: 0, Stop: 2},
+					{PID: 2, Start: 2, Stop: 6},
+					{PID: 3, Start: 7, Stop: 8},
+				},
+			},
+			want: []float64{1, 1, 1},
+		},
+		{
+			// P2 starts at 0 but is preempted by P1, finishing 6 after it started
+			name: "preempted",
+			res: Result{
+				Processes:  processes,
+				Completion: []int64{4, 6, 8},
+				Gantt: []TimeSlice{
+					{PID: 2, Start: 0, Stop: 2},
+					{PID: 1, Start: 2, Stop: 4},
+					{PID: 2, Start: 4, Stop: 6},
+					{PID: 3, Start: 7, Stop: 8},
+				},
+			},
+			want: []float64{1, 1.5, 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.res.Stretches(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Stretches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_arrivalAtCompletionOrdering(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		schedule  scheduleFunc
+		processes []Process
+		wantGantt []TimeSlice
+	}{
+		{
+			name:     "SJF dispatches the arrival at a completion",
+			schedule: infallible(SJF),
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 3},
+				{PID: 3, Start: 3, Stop: 6},
+			},
+		},
+		{
+			name:     "RR dispatches the arrival at a completion without idling",
+			schedule: RoundRobin,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 3},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 5},
+			},
+		},
+		{
+			name:     "RR queues the arrival ahead of the preempted process",
+			schedule: RoundRobin,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := mustSchedule(t, tt.schedule, tt.processes, Options{})
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			for i := range got.Processes {
+				if want := got.Completion[i] - got.Processes[i].ArrivalTime - got.Processes[i].BurstDuration; got.Wait[i] != want {
+					t.Errorf("process %d wait = %d, want %d", got.Processes[i].ProcessID, got.Wait[i], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_outputResult_deadlineColor(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Deadline: 10},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 5, Deadline: 6},
+	}
+	res := FCFS(processes, Options{})
+
+	tests := []struct {
+		name    string
+		cfg     config
+		wantRed bool
+	}{
+		{
+			name:    "color",
+			cfg:     config{color: true},
+			wantRed: true,
+		},
+		{
+			name: "no color",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputResult(&w, "First-come, first-serve", res, tt.cfg)
+
+			var missedRow, metRow string
+			plain := strings.NewReplacer(ansiRed, "", ansiReset, "")
+			for _, line := range strings.Split(w.String(), "\n") {
+				switch {
+				case strings.HasPrefix(plain.Replace(line), "|  2 |"):
+					missedRow = line
+				case strings.HasPrefix(line, "|  1 |"):
+					metRow = line
+				}
+			}
+			if missedRow == "" || metRow == "" {
+				t.Fatalf("schedule rows not found in:\n%s", w.String())
+			}
+			if got := strings.Contains(missedRow, ansiRed); got != tt.wantRed {
+				t.Errorf("missed-deadline row %q has red = %v, want %v", missedRow, got, tt.wantRed)
+			}
+			if strings.Contains(metRow, ansiRed) {
+				t.Errorf("met-deadline row %q is red", metRow)
+			}
+			if got := strings.Contains(w.String(), ansiRed+"2"+ansiReset); got != tt.wantRed {
+				t.Errorf("Gantt slice for PID 2 has red = %v, want %v", got, tt.wantRed)
+			}
+		})
+	}
+}
+
+func Test_sjf_explainSelection(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 4, ArrivalTime: 1, BurstDuration: 5},
+	}
+	var explain bytes.Buffer
+	SJF(processes, Options{Explain: &explain})
+
+	want := []string{
+		"t=0: chose P1: shortest remaining (6) among {P1:6}",
+		"t=1: chose P2: shortest remaining (3) among {P1:5,P2:3,P4:5}",
+		"t=4: chose P1: shortest remaining (5) among {P1:5,P4:5}",
+		"t=9: chose P4: shortest remaining (5) among {P4:5}",
+	}
+	if got := strings.Split(strings.TrimSpace(explain.String()), "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("explanations = %q, want %q", got, want)
+	}
+}
+
+func Test_singleProcess(t *testing.T) {
+	t.Parallel()
+	all := append([]algorithm{{"SJF event-driven", infallible(sjfEventDriven)}}, schedulers...)
+
+	for _, arrival := range []int64{0, 3} {
+		for _, s := range all {
+			arrival, s := arrival, s
+			t.Run(fmt.Sprintf("%s arriving at %d", s.title, arrival), func(t *testing.T) {
+				t.Parallel()
+				p := Process{ProcessID: 7, ArrivalTime: arrival, BurstDuration: 5, Priority: 1}
+				got := mustSchedule(t, s.schedule, []Process{p}, Options{})
+				if got.Wait[0] != 0 {
+					t.Errorf("wait = %d, want 0", got.Wait[0])
+				}
+				if got.Turnaround[0] != p.BurstDuration {
+					t.Errorf("turnaround = %d, want %d", got.Turnaround[0], p.BurstDuration)
+				}
+				if want := 1 / float64(p.BurstDuration); got.Throughput != want {
+					t.Errorf("throughput = %v, want %v", got.Throughput, want)
+				}
+				if want := []TimeSlice{{PID: 7, Start: arrival, Stop: arrival + 5}}; !reflect.DeepEqual(got.Gantt, want) {
+					t.Errorf("Gantt = %v, want %v", got.Gantt, want)
+				}
+			})
+		}
+	}
+}
+
+func Test_dispatchLatency(t *testing.T) {
+	t.Parallel()
+	// the later arrivals never outrank the first process, so every algorithm dispatches
+	// in the same order with or without the latency
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 12, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 14, Priority: 3},
+	}
+	const latency = 3
+	all := append([]algorithm{{"SJF event-driven", infallible(sjfEventDriven)}}, schedulers...)
+
+	for _, s := range all {
+		s := s
+		t.Run(s.title, func(t *testing.T) {
+			t.Parallel()
+			base := mustSchedule(t, s.schedule, processes, Options{})
+			got := mustSchedule(t, s.schedule, processes, Options{DispatchLatency: latency})
+			for i := range processes {
+				if want := base.Completion[i] + latency; got.Completion[i] != want {
+					t.Errorf("P%d completion = %d, want %d", processes[i].ProcessID, got.Completion[i], want)
+				}
+			}
+			if got.Gantt[0].Start != latency {
+				t.Errorf("first dispatch at %d, want %d", got.Gantt[0].Start, latency)
+			}
+		})
+	}
+}
+
+func Test_run_maxtime(t *testing.T) {
+	t.Parallel()
+	input := path.Join(t.TempDir(), "late.csv")
+	if err := os.WriteFile(input, []byte("1,5,0,2\n2,9,3,1\n3,6,12,3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		maxtime   string
+		wantNever string
+		wantErr   error
+	}{
+		{name: "arrival beyond the horizon", maxtime: "10", wantNever: "1 process never arrived within horizon 10\n"},
+		{name: "arrival on the horizon", maxtime: "12"},
+		{name: "no horizon", maxtime: "-1"},
+		{name: "only the first arrives", maxtime: "0", wantNever: "2 processes never arrived within horizon 0\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			if err := Run([]string{"binary_name", "-maxtime", tt.maxtime, input}, &out); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("run() error = %v, want %v", err, tt.wantErr)
+			}
+			got := strings.Contains(out.String(), "never arrived")
+			if got != (tt.wantNever != "") || !strings.HasPrefix(out.String(), tt.wantNever) {
+				t.Errorf("run() output starts %q, want %q", strings.SplitAfter(out.String(), "\n")[0], tt.wantNever)
+			}
+		})
+	}
+}
+
+func Test_scheduleFooter_totals(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	for _, res := range RunAll(processes, Options{}) {
+		res := res
+		t.Run(res.Title, func(t *testing.T) {
+			t.Parallel()
+			footer := scheduleFooter(res, true)
+			tests := []struct {
+				name    string
+				cell    string
+				average float64
+			}{
+				{name: "wait", cell: footer[4], average: res.AverageWait()},
+				{name: "turnaround", cell: footer[5], average: res.AverageTurnaround()},
+			}
+			for _, tt := range tests {
+				var average float64
+				var sum int64
+				if _, err := fmt.Sscanf(tt.cell, "Average\n%f\nTotal\n%d", &average, &sum); err != nil {
+					t.Fatalf("%s footer %q: %v", tt.name, tt.cell, err)
+				}
+				if want := tt.average * float64(len(processes)); math.Abs(float64(sum)-want) > 1e-9 {
+					t.Errorf("%s total = %d, want %.2f", tt.name, sum, want)
+				}
+			}
+			if footer := scheduleFooter(res, false); strings.Contains(footer[4]+footer[5], "Total") {
+				t.Errorf("footer without totals = %q", footer)
+			}
+		})
+	}
+}
+
+func Test_runAlgorithms_panic(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+	}
+	broken := algorithm{"Broken", func([]Process, Options) (Result, error) {
+		var queue []int64
+		return Result{Wait: []int64{queue[1]}}, nil
+	}}
+	algorithms := []algorithm{
+		{"First-come, first-serve", infallible(FCFS)},
+		broken,
+		{"Shortest-job-first", infallible(SJF)},
+	}
+
+	if _, err := runSafely(broken, processes, Options{}); !errors.Is(err, ErrSchedulerPanic) {
+		t.Errorf("runSafely() error = %v, want %v", err, ErrSchedulerPanic)
+	}
+
+	results := runAlgorithms(algorithms, processes, Options{})
+	var titles []string
+	for _, res := range results {
+		titles = append(titles, res.Title)
+	}
+	if want := []string{"First-come, first-serve", "Shortest-job-first"}; !reflect.DeepEqual(titles, want) {
+		t.Fatalf("runAlgorithms() titles = %v, want %v", titles, want)
+	}
+	var out bytes.Buffer
+	outputAll(&out, results, config{})
+	for _, title := range titles {
+		if !strings.Contains(out.String(), title) {
+			t.Errorf("output missing %s:\n%s", title, out.String())
+		}
+	}
+}
+
+func Test_rr_quantum(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 4, ArrivalTime: 1},
+	}
+	one := mustSchedule(t, RoundRobin, processes, Options{Quantum: 1})
+	four := mustSchedule(t, RoundRobin, processes, Options{Quantum: 4})
+	if reflect.DeepEqual(one.Gantt, four.Gantt) {
+		t.Errorf("quantum 1 and 4 gave the same Gantt chart %v", one.Gantt)
+	}
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 8}}
+	if !reflect.DeepEqual(four.Gantt, want) {
+		t.Errorf("quantum 4 Gantt = %v, want %v", four.Gantt, want)
+	}
+
+	for _, quantum := range []string{"0", "-2"} {
+		err := Run([]string{"binary_name", "-quantum", quantum, "../example_processes.csv"}, io.Discard)
+		if !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("run(-quantum %s) error = %v, want %v", quantum, err, ErrInvalidArgs)
+		}
+	}
+}
+
+func Test_sjf_rescansAfterCompletion(t *testing.T) {
+	t.Parallel()
+	// when P1 finishes the shortest remaining time must be reset, or P2 and P3, both
+	// longer than P1 was, would never be considered; P3 is shorter so it runs first
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 6},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 4},
+	}
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 3, Start: 2, Stop: 6},
+		{PID: 2, Start: 6, Stop: 12},
+	}
+	got := SJF(processes, Options{})
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", got.Gantt, want)
+	}
+	if wantWait := []int64{0, 5, 0}; !reflect.DeepEqual(got.Wait, wantWait) {
+		t.Errorf("Wait = %v, want %v", got.Wait, wantWait)
+	}
+}
+
+func Test_fcfs_unsortedInput(t *testing.T) {
+	t.Parallel()
+	// listed out of arrival order, with P4 and P2 arriving together
+	processes, err := LoadProcesses(strings.NewReader("3,6,6,3\n4,2,3,1\n1,5,0,2\n2,9,3,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := FCFS(processes, Options{})
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 5},
+		{PID: 2, Start: 5, Stop: 14},
+		{PID: 4, Start: 14, Stop: 16},
+		{PID: 3, Start: 16, Stop: 22},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", got.Gantt, want)
+	}
+	// results follow the input order
+	if wantWait := []int64{10, 11, 0, 2}; !reflect.DeepEqual(got.Wait, wantWait) {
+		t.Errorf("Wait = %v, want %v", got.Wait, wantWait)
+	}
+}
+
+func Test_rr_unsortedInput(t *testing.T) {
+	t.Parallel()
+	sorted, err := LoadProcesses(strings.NewReader("1,5,0,2\n2,9,3,1\n3,6,6,3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first process listed arrives last
+	unsorted, err := LoadProcesses(strings.NewReader("3,6,6,3\n1,5,0,2\n2,9,3,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := mustSchedule(t, RoundRobin, sorted, Options{})
+	got := mustSchedule(t, RoundRobin, unsorted, Options{})
+	if !reflect.DeepEqual(got.Gantt, want.Gantt) {
+		t.Errorf("Gantt = %v, want %v", got.Gantt, want.Gantt)
+	}
+	// results follow the input order
+	for i, j := range []int{2, 0, 1} {
+		if got.Wait[i] != want.Wait[j] || got.Turnaround[i] != want.Turnaround[j] || got.Completion[i] != want.Completion[j] {
+			t.Errorf("P%d wait, turnaround, completion = %d, %d, %d, want %d, %d, %d", got.Processes[i].ProcessID,
+				got.Wait[i], got.Turnaround[i], got.Completion[i], want.Wait[j], want.Turnaround[j], want.Completion[j])
+		}
+	}
+}
+
+func Test_run_algorithm(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "fcfs only",
+			args: []string{"-algorithm=fcfs"},
+			want: []string{"First-come, first-serve"},
+		},
+		{
+			name: "with an extra one",
+			args: []string{"-algorithm", "rr", "-with", "wfq"},
+			want: []string{"Round-robin", "Weighted fair queuing"},
+		},
+		{
+			name: "all",
+			args: []string{"-algorithm", "all"},
+			want: []string{"First-come, first-serve", "Shortest-job-first", "Priority", "Round-robin"},
+		},
+		{
+			name:    "unknown",
+			args:    []string{"-algorithm", "stride"},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			args := append(append([]string{"binary_name"}, tt.args...), "../example_processes.csv")
+			if err := Run(args, &out); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("run() error = %v, want %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, line := range strings.Split(out.String(), "\n") {
+				// titles are the lines set between two rules of dashes
+				if strings.HasPrefix(line, " ") && strings.TrimSpace(line) != "" && !strings.Contains(line, "|") {
+					got = append(got, strings.TrimSpace(line))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("titles = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rr_responseTimes(t *testing.T) {
+	t.Parallel()
+	// P1 and P2 take turns, so each first runs long before it has done all its waiting
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	res := mustSchedule(t, RoundRobin, processes, Options{})
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 1, Start: 4, Stop: 6},
+		{PID: 2, Start: 6, Stop: 7},
+		{PID: 1, Start: 7, Stop: 8},
+	}
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Fatalf("Gantt = %v, want %v", res.Gantt, want)
+	}
+	// response counts only the wait before the first run, not later resumptions
+	if got, want := res.ResponseTimes(), []int64{0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ResponseTimes() = %v, want %v", got, want)
+	}
+	if want := []int64{3, 3}; !reflect.DeepEqual(res.Wait, want) {
+		t.Errorf("Wait = %v, want %v", res.Wait, want)
+	}
+	if footer := scheduleFooter(res, false); footer[6] != "Average\n0.50" {
+		t.Errorf("response footer = %q, want the average response", footer[6])
+	}
+}
+
+func Test_rr_idleGapWait(t *testing.T) {
+	t.Parallel()
+	// the CPU idles from 5 to 8 between two busy stretches, and nobody is waiting then
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 8, BurstDuration: 4},
+		{ProcessID: 4, ArrivalTime: 9, BurstDuration: 2},
+	}
+	res := mustSchedule(t, RoundRobin, processes, Options{})
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 1, Start: 4, Stop: 5},
+		{PID: 3, Start: 8, Stop: 10},
+		{PID: 4, Start: 10, Stop: 12},
+		{PID: 3, Start: 12, Stop: 14},
+	}
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Fatalf("Gantt = %v, want %v", res.Gantt, want)
+	}
+	if want := []int64{2, 1, 2, 1}; !reflect.DeepEqual(res.Wait, want) {
+		t.Errorf("Wait = %v, want %v", res.Wait, want)
+	}
+	for i := range processes {
+		if res.Wait[i] != res.Turnaround[i]-processes[i].BurstDuration {
+			t.Errorf("P%d wait %d is not turnaround %d minus burst %d",
+				processes[i].ProcessID, res.Wait[i], res.Turnaround[i], processes[i].BurstDuration)
+		}
+	}
+}
+
+func Test_rr_queue(t *testing.T) {
+	t.Parallel()
+	// every case but the simultaneous arrivals matches the schedule from before the ready
+	// queue was a FIFO, which lost all but one of the processes arriving together
+	tests := []struct {
+		name      string
+		processes []Process
+		opts      Options
+		want      []TimeSlice
+		wantWait  []int64
+	}{
+		{
+			name: "example input",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3},
+				{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 7},
+				{PID: 3, Start: 7, Stop: 9},
+				{PID: 2, Start: 9, Stop: 11},
+				{PID: 3, Start: 11, Stop: 13},
+				{PID: 2, Start: 13, Stop: 15},
+				{PID: 3, Start: 15, Stop: 17},
+				{PID: 2, Start: 17, Stop: 20},
+			},
+			wantWait: []int64{2, 8, 5},
+		},
+		{
+			// P3 and P4 arrive during P1's second quantum, so they run before it again
+			name: "arrivals ahead of the preempted process",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 7},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 4},
+				{ProcessID: 3, ArrivalTime: 4, BurstDuration: 1},
+				{ProcessID: 4, ArrivalTime: 5, BurstDuration: 4},
+			},
+			opts: Options{Quantum: 3},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 3, Stop: 6},
+				{PID: 1, Start: 6, Stop: 9},
+				{PID: 3, Start: 9, Stop: 10},
+				{PID: 4, Start: 10, Stop: 13},
+				{PID: 2, Start: 13, Stop: 14},
+				{PID: 1, Start: 14, Stop: 15},
+				{PID: 4, Start: 15, Stop: 16},
+			},
+			wantWait: []int64{8, 8, 5, 7},
+		},
+		{
+			name: "suspended process gives up its turn",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2},
+			},
+			opts: Options{Suspensions: []Suspension{{PID: 1, From: 2, To: 6}}},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 3, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 8},
+				{PID: 2, Start: 8, Stop: 9},
+			},
+			wantWait: []int64{0, 5, 2},
+		},
+		{
+			name: "everything suspended",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+			},
+			opts: Options{Suspensions: []Suspension{{PID: 1, From: 1, To: 4}, {PID: 2, From: 1, To: 4}}},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 7},
+				{PID: 2, Start: 7, Stop: 8},
+			},
+			wantWait: []int64{2, 1},
+		},
+		{
+			name: "dispatch latency and setup",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Class: "A"},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Class: "B"},
+			},
+			opts: Options{DispatchLatency: 2, Setup: SetupTimes{Default: 1}},
+			want: []TimeSlice{
+				{PID: 1, Start: 2, Stop: 4},
+				{PID: 2, Start: 5, Stop: 7},
+				{PID: 1, Start: 8, Stop: 9},
+				{PID: 2, Start: 10, Stop: 11},
+			},
+			wantWait: []int64{6, 7},
+		},
+		{
+			name: "simultaneous arrivals",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1},
+			},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 3, Start: 4, Stop: 5},
+				{PID: 1, Start: 5, Stop: 6},
+			},
+			wantWait: []int64{3, 2, 4},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RoundRobin(tt.processes, tt.opts)
+			if err != nil {
+				t.Fatalf("RoundRobin() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.want)
+			}
+			if !reflect.DeepEqual(got.Wait, tt.wantWait) {
+				t.Errorf("Wait = %v, want %v", got.Wait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func Test_rr_noProgress(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	stuck := Options{Suspensions: []Suspension{{PID: 2, From: 1, To: 1 << 40}}}
+
+	// a suspension far longer than the workload would idle the CPU for ages
+	if _, err := RoundRobin(processes, stuck); !errors.Is(err, ErrNoProgress) {
+		t.Errorf("RoundRobin() error = %v, want %v", err, ErrNoProgress)
+	}
+	// the CLI reports it as a failed algorithm rather than a panic
+	_, err := runSafely(algorithm{"Round-robin", RoundRobin}, processes, stuck)
+	if !errors.Is(err, ErrNoProgress) || errors.Is(err, ErrSchedulerPanic) {
+		t.Errorf("runSafely() error = %v, want %v", err, ErrNoProgress)
+	}
+	// turns that run nothing never move time on, however far off the time limit is
+	if _, err := rrArrivalOrdered(processes, Options{RRQuanta: []int64{0}}); !errors.Is(err, ErrNoProgress) {
+		t.Errorf("rrArrivalOrdered() with quanta [0] error = %v, want %v", err, ErrNoProgress)
+	}
+
+	// a long suspension the workload could plausibly wait out still runs
+	res, err := RoundRobin(processes, Options{Suspensions: []Suspension{{PID: 2, From: 1, To: 20}}})
+	if err != nil {
+		t.Fatalf("RoundRobin() error = %v", err)
+	}
+	if want := []int64{3, 22}; !reflect.DeepEqual(res.Completion, want) {
+		t.Errorf("Completion = %v, want %v", res.Completion, want)
+	}
+}
+
+func Test_RRScheduleVariable(t *testing.T) {
+	t.Parallel()
+	processes := loadTestdata(t, "rr_variable.csv")
+
+	// the long P1 runs for 2, then 4, then 8 ticks as its turns come round
+	var w bytes.Buffer
+	res, err := RRScheduleVariable(&w, "Round-robin", processes, []int64{2, 4, 8})
+	if err != nil {
+		t.Fatalf("RRScheduleVariable() error = %v", err)
+	}
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 3, Start: 4, Stop: 6},
+		{PID: 1, Start: 6, Stop: 10},
+		{PID: 2, Start: 10, Stop: 11},
+		{PID: 1, Start: 11, Stop: 19},
+	}
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("RRScheduleVariable() Gantt = %v, want %v", res.Gantt, want)
+	}
+	if want := loadFixture(t, "testdata/rr_variable.golden"); w.String() != want {
+		t.Errorf("RRScheduleVariable() output = %s, want %s", w.String(), want)
+	}
+
+	// a single quantum is plain round-robin
+	got, plain := mustSchedule(t, RoundRobin, processes, Options{RRQuanta: []int64{3}}), mustSchedule(t, RoundRobin, processes, Options{Quantum: 3})
+	if !reflect.DeepEqual(got, plain) {
+		t.Errorf("RoundRobin() with quanta [3] = %v, want %v", got, plain)
+	}
+
+	// a quantum under one tick would never run anything
+	if _, err := RRScheduleVariable(io.Discard, "Round-robin", processes, []int64{2, 0}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("RRScheduleVariable() with quanta [2 0] error = %v, want %v", err, ErrInvalidArgs)
+	}
+	err = Run([]string{"scheduler", "-algorithm=rr", "-quantum-per-queue=0", "testdata/rr_variable.csv"}, io.Discard)
+	if !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("Run() with -quantum-per-queue=0 error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_contextSwitches(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+	}
+	first := FCFS(processes, Options{})
+	if got := first.ContextSwitches(); got != len(processes)-1 {
+		t.Errorf("FCFS context switches = %d, want %d", got, len(processes)-1)
+	}
+	robin := mustSchedule(t, RoundRobin, processes, Options{Quantum: 1})
+	if robin.ContextSwitches() <= first.ContextSwitches() {
+		t.Errorf("RR with quantum 1 context switches = %d, want more than FCFS's %d",
+			robin.ContextSwitches(), first.ContextSwitches())
+	}
+
+	var out bytes.Buffer
+	outputResult(&out, robin.Title, robin, config{details: true})
+	if want := fmt.Sprintf("Context switches: %d\n", robin.ContextSwitches()); !strings.Contains(out.String(), want) {
+		t.Errorf("output missing %q:\n%s", want, out.String())
+	}
+	// the further metrics are only written with -details
+	out.Reset()
+	outputResult(&out, robin.Title, robin, config{})
+	if strings.Contains(out.String(), "Context switches") {
+		t.Errorf("output without -details has the context switches:\n%s", out.String())
+	}
+}
+
+func Test_isHeaderRow(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		row  []string
+		want bool
+	}{
+		{row: []string{"pid", "burst", "arrival"}, want: true},
+		{row: []string{" ProcessID", " Burst Duration ", "Arrival Time", "Class"}, want: true},
+		{row: []string{"1", "5", "0"}, want: false},
+		{row: []string{"1", "5", "0", "2", "0", "A"}, want: false},
+		{row: []string{"pid", " 5", "arrival"}, want: false},
+	}
+	for _, tt := range tests {
+		if got := isHeaderRow(tt.row); got != tt.want {
+			t.Errorf("isHeaderRow(%q) = %v, want %v", tt.row, got, tt.want)
+		}
+	}
+}
+
+func Test_loadProcesses_errorPosition(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "bad burst", input: "1,5,0\n2,soon,3\n", want: "row 2 col 2"},
+		{name: "bad priority after a header", input: "pid,burst,arrival,priority\n1,5,0,high\n", want: "row 2 col 4"},
+		{name: "short row", input: "1,5,0\n2,9\n", want: "row 2 has 2 fields"},
+		{name: "too long row", input: "1,5,0\n2,9,3,1,0,A,1,1,0,0,1,7\n", want: "row 2 has 12 fields"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := LoadProcesses(strings.NewReader(tt.input))
+			if !errors.Is(err, ErrInvalidArgs) {
+				t.Fatalf("loadProcesses() error = %v, want %v", err, ErrInvalidArgs)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("loadProcesses() error = %q, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("parse error", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadProcesses(strings.NewReader("1,5,0\n2,soon,3\n"))
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			t.Fatalf("loadProcesses() error = %v, want a *FieldError", err)
+		}
+		if fieldErr.Row != 2 || fieldErr.Col != 2 {
+			t.Errorf("FieldError at row %d col %d, want row 2 col 2", fieldErr.Row, fieldErr.Col)
+		}
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) || numErr.Num != "soon" {
+			t.Errorf("loadProcesses() error = %v, want it to wrap the *strconv.NumError for %q", err, "soon")
+		}
+	})
+}
+
+func Test_validateProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   error
+	}{
+		{
+			name: "valid",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 1},
+			},
+		},
+		{name: "none"},
+		{
+			name:      "zero burst",
+			processes: []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 0}},
+			wantErr:   ErrInvalidArgs,
+		},
+		{
+			name:      "negative burst",
+			processes: []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: -3}},
+			wantErr:   ErrInvalidArgs,
+		},
+		{
+			name:      "negative arrival",
+			processes: []Process{{ProcessID: 1, ArrivalTime: -1, BurstDuration: 2}},
+			wantErr:   ErrInvalidArgs,
+		},
+		{
+			name: "duplicate PID",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 1, ArrivalTime: 3, BurstDuration: 1},
+			},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateProcesses(tt.processes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateProcesses() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_throughput_consistentMakespan(t *testing.T) {
+	t.Parallel()
+	// the first arrival is late and the CPU idles from 7 to 10; every algorithm finishes
+	// at 14, so all should divide by the makespan 14-2, not by when their loop stopped
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 10, BurstDuration: 4, Priority: 1},
+	}
+	all := append([]algorithm{}, schedulers...)
+	for _, name := range []string{"wfq", "sjf-np", "sjf-lookahead", "srtf", "hrrn", "ljf", "lrtf", "mlfq", "edf", "rm", "lottery"} {
+		all = append(all, extraSchedulers[name])
+	}
+
+	for _, s := range all {
+		s := s
+		t.Run(s.title, func(t *testing.T) {
+			t.Parallel()
+			got := mustSchedule(t, s.schedule, processes, Options{})
+			if span := Makespan(got.Processes, got.Gantt); span != 12 {
+				t.Errorf("makespan = %d, want 12", span)
+			}
+			if want := 3.0 / 12; got.Throughput != want {
+				t.Errorf("throughput = %v, want %v", got.Throughput, want)
+			}
+		})
+	}
+}
+
+func Test_responseRatios(t *testing.T) {
+	t.Parallel()
+	// under FCFS the short P2 waits behind the long P1, while P1 never waits
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 8, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1},
+	}
+	res := FCFS(processes, Options{})
+
+	if got, want := res.NormalizedTurnarounds(), []float64{1, 4.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizedTurnarounds() = %v, want %v", got, want)
+	}
+	if got, want := res.ResponseRatios(), []float64{1, 4.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ResponseRatios() = %v, want %v", got, want)
+	}
+
+	footer := scheduleFooter(res, false)
+	if got := footer[7:9]; !reflect.DeepEqual(got, []string{"Average\n2.75", "Average\n2.75"}) {
+		t.Errorf("scheduleFooter() averages = %q, want both %q", got, "Average\n2.75")
+	}
+
+	// under SJF P2 preempts P1, which started at once but waits 2 ticks to finish
+	res = SJF(processes, Options{})
+	if got, want := res.NormalizedTurnarounds(), []float64{1.25, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SJF NormalizedTurnarounds() = %v, want %v", got, want)
+	}
+	if got, want := res.ResponseRatios(), []float64{1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SJF ResponseRatios() = %v, want %v", got, want)
+	}
+}
+
+func Test_testdataSchedules(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		file     string
+		schedule scheduleFunc
+		opts     Options
+		want     []TimeSlice
+		wantWait []int64
+		// check makes any further checks particular to the scheduler
+		check func(t *testing.T, processes []Process, got Result)
+	}{
+		{
+			// P3 and P5 are equally long, so P3 goes first for arriving earlier
+			name:     "LJF",
+			file:     "ljf.csv",
+			schedule: infallible(ljf),
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 6},
+				{PID: 3, Start: 6, Stop: 14},
+				{PID: 5, Start: 14, Stop: 22},
+				{PID: 4, Start: 22, Stop: 25},
+				{PID: 2, Start: 25, Stop: 27},
+			},
+			wantWait: []int64{0, 24, 4, 19, 10},
+			check: func(t *testing.T, processes []Process, got Result) {
+				if sjf := SJF(processes, Options{}); got.AverageWait() <= sjf.AverageWait() {
+					t.Errorf("average wait %.2f, want more than SJF's %.2f", got.AverageWait(), sjf.AverageWait())
+				}
+			},
+		},
+		{
+			// each arrival is longer than what is running, and once the three are level
+			// they take turns a tick at a time
+			name:     "LRTF",
+			file:     "lrtf.csv",
+			schedule: infallible(lrtf),
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 2},
+				{PID: 3, Start: 2, Stop: 4},
+				{PID: 2, Start: 4, Stop: 5},
+				{PID: 3, Start: 5, Stop: 6},
+				{PID: 1, Start: 6, Stop: 7},
+				{PID: 2, Start: 7, Stop: 8},
+				{PID: 3, Start: 8, Stop: 9},
+				{PID: 1, Start: 9, Stop: 10},
+				{PID: 2, Start: 10, Stop: 11},
+				{PID: 3, Start: 11, Stop: 12},
+				{PID: 1, Start: 12, Stop: 13},
+				{PID: 2, Start: 13, Stop: 14},
+				{PID: 3, Start: 14, Stop: 15},
+			},
+			wantWait: []int64{9, 8, 7},
+			check: func(t *testing.T, processes []Process, got Result) {
+				if srtf := sjfEventDriven(processes, Options{}); got.AverageTurnaround() <= srtf.AverageTurnaround() {
+					t.Errorf("average turnaround %.2f, want more than SRTF's %.2f", got.AverageTurnaround(), srtf.AverageTurnaround())
+				}
+			},
+		},
+		{
+			// P1 and P2 use up the top quantum and drop a queue; P3's arrival preempts P1
+			// in the second queue, and P1 finally sinks to the bottom queue alone
+			name:     "MLFQ with the default quanta",
+			file:     "mlfq.csv",
+			schedule: infallible(mlfq),
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 5},
+				{PID: 3, Start: 5, Stop: 6},
+				{PID: 2, Start: 6, Stop: 7},
+				{PID: 1, Start: 7, Stop: 11},
+				{PID: 1, Start: 11, Stop: 14},
+			},
+		},
+		{
+			// with a single queue MLFQ is round-robin
+			name:     "MLFQ with one queue",
+			file:     "mlfq.csv",
+			schedule: infallible(mlfq),
+			opts:     Options{MLFQQuanta: []int64{3}},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 3, Stop: 6},
+				{PID: 1, Start: 6, Stop: 9},
+				{PID: 3, Start: 9, Stop: 10},
+				{PID: 1, Start: 10, Stop: 13},
+				{PID: 1, Start: 13, Stop: 14},
+			},
+		},
+		{
+			// P2 is due first and preempts P1; P3 is due before P1 too, which leaves P1
+			// finishing at 11, past its deadline of 10
+			name:     "EDF",
+			file:     "edf.csv",
+			schedule: infallible(edf),
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 4},
+				{PID: 3, Start: 4, Stop: 8},
+				{PID: 1, Start: 8, Stop: 11},
+			},
+			check: func(t *testing.T, processes []Process, got Result) {
+				if missed := got.MissedDeadlines(); missed != 1 {
+					t.Errorf("MissedDeadlines() = %d, want 1", missed)
+				}
+				var out bytes.Buffer
+				EDFSchedule(&out, "EDF", processes)
+				if !strings.Contains(out.String(), "Missed deadlines: 1\n") {
+					t.Errorf("output missing the missed deadline count:\n%s", out.String())
+				}
+			},
+		},
+		{
+			// P1 (period 4) preempts P2 (period 6) at each release, so P2's first instance
+			// only finishes at 7, past its deadline of 6, before its second runs on
+			name:     "rate monotonic",
+			file:     "rm.csv",
+			schedule: infallible(rateMonotonic),
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 6},
+				{PID: 2, Start: 6, Stop: 8},
+				{PID: 1, Start: 8, Stop: 10},
+				{PID: 2, Start: 10, Stop: 12},
+			},
+			check: func(t *testing.T, processes []Process, got Result) {
+				if h := hyperperiod(processes); h != 12 {
+					t.Errorf("hyperperiod() = %d, want 12", h)
+				}
+				// instances are listed per task in release order: P1 at 0, 4 and 8, then P2
+				// at 0 and 6
+				var releases, completions []int64
+				for i, p := range got.Processes {
+					releases = append(releases, p.ArrivalTime)
+					completions = append(completions, got.Completion[i])
+				}
+				if wantReleases := []int64{0, 4, 8, 0, 6}; !reflect.DeepEqual(releases, wantReleases) {
+					t.Errorf("releases = %v, want %v", releases, wantReleases)
+				}
+				if wantCompletions := []int64{2, 6, 10, 7, 12}; !reflect.DeepEqual(completions, wantCompletions) {
+					t.Errorf("completions = %v, want %v", completions, wantCompletions)
+				}
+				if missed := got.MissedDeadlines(); missed != 1 {
+					t.Errorf("MissedDeadlines() = %d, want 1", missed)
+				}
+				// P1's later instances are matched with their own slices, not the first
+				// instance's
+				if response := got.ResponseTimes()[:3]; !reflect.DeepEqual(response, []int64{0, 0, 0}) {
+					t.Errorf("ResponseTimes() for P1 = %v, want [0 0 0]", response)
+				}
+			},
+		},
+		{
+			// one slice per drawing, so the slices give the winners in turn
+			name:     "lottery",
+			file:     "lottery.csv",
+			schedule: infallible(lottery),
+			opts:     Options{LotterySeed: 42},
+			want: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 6},
+				{PID: 3, Start: 6, Stop: 8},
+				{PID: 3, Start: 8, Stop: 10},
+				{PID: 2, Start: 10, Stop: 12},
+				{PID: 3, Start: 12, Stop: 14},
+				{PID: 1, Start: 14, Stop: 16},
+				{PID: 1, Start: 16, Stop: 18},
+			},
+			wantWait: []int64{12, 6, 7},
+			check: func(t *testing.T, processes []Process, got Result) {
+				if tickets := []int64{processes[0].Tickets, processes[1].Tickets, processes[2].Tickets}; !reflect.DeepEqual(tickets, []int64{1, 3, 6}) {
+					t.Errorf("tickets = %v, want [1 3 6]", tickets)
+				}
+				if wantWins := []int64{3, 3, 3}; !reflect.DeepEqual(got.Wins, wantWins) {
+					t.Errorf("Wins = %v, want %v", got.Wins, wantWins)
+				}
+				if again := LotterySchedule(io.Discard, "Lottery", processes, 42); !reflect.DeepEqual(again.Gantt, got.Gantt) {
+					t.Errorf("the same seed gave Gantt %v, then %v", got.Gantt, again.Gantt)
+				}
+			},
+		},
+		{
+			// at time 3, P2 has waited long enough that its ratio of 1.25 beats the freshly
+			// arrived P3's 1, though SJF would run P3
+			name:     "HRRN",
+			file:     "hrrn.csv",
+			schedule: infallible(hrrn),
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 3, Stop: 11},
+				{PID: 3, Start: 11, Stop: 13},
+			},
+			check: func(t *testing.T, processes []Process, got Result) {
+				if sjf := sjfNonPreemptive(processes, Options{}); sjf.Gantt[1].PID != 3 {
+					t.Errorf("non-preemptive SJF ran P%d second, want P3", sjf.Gantt[1].PID)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes := loadTestdata(t, tt.file)
+			got := mustSchedule(t, tt.schedule, processes, tt.opts)
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.want)
+			}
+			if tt.wantWait != nil && !reflect.DeepEqual(got.Wait, tt.wantWait) {
+				t.Errorf("Wait = %v, want %v", got.Wait, tt.wantWait)
+			}
+			if tt.check != nil {
+				tt.check(t, processes, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+// selectNext returns the eligible process that compare ranks first, or -1 if none is
+// eligible. compare returns a negative number if process a should run before process b, a
+// positive one if b should run before a, and 0 if it has no preference, in which case
+// tieBreak decides. The choice never depends on where a process is listed, so reordering
+// the input cannot change the schedule.
+func selectNext(processes []Process, eligible func(i int) bool, compare func(a, b int) int) int {
+	next := -1
+	for i := range processes {
+		if !eligible(i) {
+			continue
+		}
+		if next == -1 {
+			next = i
+			continue
+		}
+		c := compare(i, next)
+		if c == 0 {
+			c = tieBreak(processes, i, next)
+		}
+		if c < 0 {
+			next = i
+		}
+	}
+
+	return next
+}
+
+// tieBreak orders processes a and b, which the scheduler has no preference between, by
+// earliest arrival, then shortest burst, then lowest PID.
+func tieBreak(processes []Process, a, b int) int {
+	if c := compareInt64(processes[a].ArrivalTime, processes[b].ArrivalTime); c != 0 {
+		return c
+	}
+	if c := compareInt64(processes[a].BurstDuration, processes[b].BurstDuration); c != 0 {
+		return c
+	}
+
+	return compareInt64(processes[a].ProcessID, processes[b].ProcessID)
+}
+
+// tieBreaks lists the orders -tie-break can settle the SJF and priority schedulers' ties in.
+var tieBreaks = map[string]bool{"arrival": true, "pid": true, "priority": true}
+
+// tieBreak orders processes a and b, which the scheduler has no preference between, as
+// Options.TieBreak chooses: by lowest PID, by best (lowest) priority, or for "arrival" or
+// none by tieBreak's own order, which any remaining tie falls back to.
+func (o Options) tieBreak(processes []Process, a, b int) int {
+	switch o.TieBreak {
+	case "pid":
+		return compareInt64(processes[a].ProcessID, processes[b].ProcessID)
+	case "priority":
+		if c := compareInt64(processes[a].Priority, processes[b].Priority); c != 0 {
+			return c
+		}
+	}
+
+	return tieBreak(processes, a, b)
+}
+
+// preferRunning favours the running process, if it is a or b, so a preemptive scheduler
+// does not switch away from it for a process it ranks equal.
+func preferRunning(running, a, b int) int {
+	switch running {
+	case a:
+		return -1
+	case b:
+		return 1
+	}
+
+	return 0
+}
+
+// compareInt64 returns -1 if a < b, 1 if a > b and 0 if they are equal.
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
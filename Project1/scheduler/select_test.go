@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_selectNext_tieBreak(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 4, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+	}
+	noPreference := func(a, b int) int { return 0 }
+
+	// with no preference, the earliest arrival, then shortest burst, then lowest PID wins
+	var order []int64
+	done := make([]bool, len(processes))
+	for {
+		next := selectNext(processes, func(i int) bool { return !done[i] }, noPreference)
+		if next == -1 {
+			break
+		}
+		done[next] = true
+		order = append(order, processes[next].ProcessID)
+	}
+	if want := []int64{1, 2, 3, 4}; !reflect.DeepEqual(order, want) {
+		t.Errorf("selection order = %v, want %v", order, want)
+	}
+}
+
+func Test_schedulers_inputOrderIndependent(t *testing.T) {
+	t.Parallel()
+	// P2, P3 and P4 arrive together with equal bursts and priorities, so only the
+	// tie-break separates them
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 3, BurstDuration: 3, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 4, BurstDuration: 3, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 5, BurstDuration: 2, ArrivalTime: 5, Priority: 1},
+	}
+
+	tests := []struct {
+		name     string
+		schedule scheduleFunc
+	}{
+		{name: "SJF", schedule: infallible(SJF)},
+		{name: "Priority", schedule: infallible(SJFPriority)},
+		{name: "HRRN", schedule: infallible(hrrn)},
+		{name: "SRTF", schedule: infallible(sjfEventDriven)},
+		{name: "SJF non-preemptive", schedule: infallible(sjfNonPreemptive)},
+		{name: "LJF", schedule: infallible(ljf)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			want := mustSchedule(t, tt.schedule, processes, Options{})
+			for seed := int64(0); seed < 20; seed++ {
+				got := mustSchedule(t, tt.schedule, shuffleProcesses(processes, seed), Options{})
+				if !reflect.DeepEqual(got.Gantt, want.Gantt) {
+					t.Errorf("seed %d: Gantt = %v, want %v", seed, got.Gantt, want.Gantt)
+				}
+				if !reflect.DeepEqual(timingsByPID(got), timingsByPID(want)) {
+					t.Errorf("seed %d: timings = %v, want %v", seed, timingsByPID(got), timingsByPID(want))
+				}
+			}
+		})
+	}
+}
+
+func Test_tieBreakOption(t *testing.T) {
+	t.Parallel()
+	// when P9 finishes at 2, P1, P2 and P3 are tied on remaining time: P2 arrived first,
+	// P1 has the lowest PID and P3 the best priority
+	processes := []Process{
+		{ProcessID: 9, ArrivalTime: 0, BurstDuration: 2, Priority: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 2},
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 3},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+	}
+	// with equal priorities the priority scheduler is left with the same ties
+	equal := make([]Process, len(processes))
+	for i, p := range processes {
+		p.Priority = 1
+		equal[i] = p
+	}
+
+	tests := []struct {
+		name      string
+		schedule  scheduleFunc
+		processes []Process
+		tieBreak  string
+		want      []int64
+	}{
+		{name: "SJF by default", schedule: infallible(SJF), processes: processes, want: []int64{9, 2, 1, 3}},
+		{name: "SJF by arrival", schedule: infallible(SJF), processes: processes, tieBreak: "arrival", want: []int64{9, 2, 1, 3}},
+		{name: "SJF by PID", schedule: infallible(SJF), processes: processes, tieBreak: "pid", want: []int64{9, 1, 2, 3}},
+		{name: "SJF by priority", schedule: infallible(SJF), processes: processes, tieBreak: "priority", want: []int64{9, 3, 2, 1}},
+		{name: "SRTF by PID", schedule: infallible(sjfEventDriven), processes: processes, tieBreak: "pid", want: []int64{9, 1, 2, 3}},
+		{name: "Priority by arrival", schedule: infallible(SJFPriority), processes: equal, tieBreak: "arrival", want: []int64{9, 2, 1, 3}},
+		{name: "Priority by PID", schedule: infallible(SJFPriority), processes: equal, tieBreak: "pid", want: []int64{9, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []int64
+			for _, slice := range mustSchedule(t, tt.schedule, tt.processes, Options{TieBreak: tt.tieBreak}).Gantt {
+				got = append(got, slice.PID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("run order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := Run([]string{"binary_name", "-tie-break", "random", "../example_processes.csv"}, io.Discard); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("run(-tie-break random) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
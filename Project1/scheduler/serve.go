@@ -0,0 +1,204 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
+
+const (
+	// maxRequestBytes is the largest request body the server reads.
+	maxRequestBytes = 1 << 20
+	// maxServeProcesses is the most processes, and CPUs, a request may ask for.
+	maxServeProcesses = 1000
+	// maxServeTicks bounds the ticks a request may ask for: each arrival, the bursts put
+	// together, and every delay or event time in the options.
+	maxServeTicks = 100_000
+)
+
+// scheduleRequest is the body accepted by POST /schedule.
+type scheduleRequest struct {
+	Processes []Process `json:"processes"`
+	// Options tune the schedulers as the command's flags do; left out, they are the
+	// defaults.
+	Options Options `json:"options"`
+}
+
+// serve answers scheduling requests over HTTP on addr until the server fails.
+func serve(addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           newServeMux(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	return srv.ListenAndServe()
+}
+
+func newServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/schedule", handleSchedule)
+
+	return mux
+}
+
+// handleSchedule runs every scheduler over the posted processes with the posted options
+// and responds with their results as JSON, or with an error if any scheduler fails.
+func handleSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req scheduleRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), status)
+		return
+	}
+	if err := validateRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	processes, err := effectiveBursts(req.Processes, 1)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// unlike RunAll, a scheduler that fails fails the request rather than going missing
+	results := make([]Result, 0, len(schedulers))
+	for _, a := range schedulers {
+		res, err := runSafely(a, processes, req.Options)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+		results = append(results, res)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// errorStatus returns the HTTP status for a scheduler's error: the request's fault if its
+// input was invalid or could not be scheduled, the server's otherwise.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidArgs):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrNoProgress):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+// validateRequest rejects inputs the schedulers cannot handle, or that would keep the
+// server busy for too long.
+func validateRequest(req scheduleRequest) error {
+	if len(req.Processes) == 0 {
+		return fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	}
+	if len(req.Processes) > maxServeProcesses {
+		return fmt.Errorf("%w: %d processes, want at most %d", ErrInvalidArgs, len(req.Processes), maxServeProcesses)
+	}
+	if err := validateProcesses(req.Processes); err != nil {
+		return err
+	}
+	var bursts int64
+	pids := make(map[int64]bool, len(req.Processes))
+	for _, p := range req.Processes {
+		if p.ArrivalTime > maxServeTicks {
+			return fmt.Errorf("%w: process %d arrives at %d, want at most %d", ErrInvalidArgs, p.ProcessID, p.ArrivalTime, maxServeTicks)
+		}
+		if bursts += p.BurstDuration; bursts > maxServeTicks {
+			return fmt.Errorf("%w: bursts add up to more than %d", ErrInvalidArgs, maxServeTicks)
+		}
+		pids[p.ProcessID] = true
+	}
+	if err := validateOptions(req.Options); err != nil {
+		return err
+	}
+
+	for _, s := range req.Options.Suspensions {
+		if !pids[s.PID] {
+			return fmt.Errorf("%w: suspension of unknown process %d", ErrInvalidArgs, s.PID)
+		}
+		if s.From < 0 || s.To < s.From || s.To > maxServeTicks {
+			return fmt.Errorf("%w: process %d suspended from %d to %d, want 0 <= from <= to <= %d",
+				ErrInvalidArgs, s.PID, s.From, s.To, maxServeTicks)
+		}
+	}
+	for _, c := range req.Options.PriorityChanges {
+		if !pids[c.PID] {
+			return fmt.Errorf("%w: priority change of unknown process %d", ErrInvalidArgs, c.PID)
+		}
+		if c.Time < 0 || c.Time > maxServeTicks {
+			return fmt.Errorf("%w: process %d changes priority at %d, want 0 to %d", ErrInvalidArgs, c.PID, c.Time, maxServeTicks)
+		}
+	}
+
+	return nil
+}
+
+// validateOptions rejects options no scheduler can run with, as the command's flags do,
+// and delays long enough to keep the server busy for too long.
+func validateOptions(opts Options) error {
+	for _, o := range []struct {
+		name  string
+		value int64
+		max   int64
+	}{
+		{"setup time", opts.Setup.Default, maxServeTicks},
+		{"dispatch latency", opts.DispatchLatency, maxServeTicks},
+		{"power-down idle time", opts.PowerDown.After, maxServeTicks},
+		{"wake penalty", opts.PowerDown.Wake, maxServeTicks},
+		{"preemption granularity", opts.PreemptGranularity, math.MaxInt64},
+		{"round-robin quantum", opts.Quantum, math.MaxInt64},
+		{"aging", opts.Aging, math.MaxInt64},
+		{"CPUs", int64(opts.CPUs), maxServeProcesses},
+	} {
+		if o.value < 0 {
+			return fmt.Errorf("%w: %s %d, want >= 0", ErrInvalidArgs, o.name, o.value)
+		}
+		if o.value > o.max {
+			return fmt.Errorf("%w: %s %d, want at most %d", ErrInvalidArgs, o.name, o.value, o.max)
+		}
+	}
+	for from, to := range opts.Setup.Matrix {
+		for class, t := range to {
+			if t < 0 || t > maxServeTicks {
+				return fmt.Errorf("%w: setup time %d from class %q to %q, want 0 to %d",
+					ErrInvalidArgs, t, from, class, maxServeTicks)
+			}
+		}
+	}
+	for _, quanta := range [][]int64{opts.RRQuanta, opts.MLFQQuanta} {
+		for _, q := range quanta {
+			if q < 1 {
+				return fmt.Errorf("%w: quantum %d, want >= 1", ErrInvalidArgs, q)
+			}
+		}
+	}
+	if opts.TieBreak != "" && !tieBreaks[opts.TieBreak] {
+		return fmt.Errorf("%w: unknown tie-break order %q", ErrInvalidArgs, opts.TieBreak)
+	}
+
+	return opts.checkCPUs()
+}
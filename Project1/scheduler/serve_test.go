@@ -0,0 +1,222 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_handleSchedule(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(newServeMux())
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:   "success",
+			method: http.MethodPost,
+			body: `{"processes": [
+				{"pid": 1, "arrival": 0, "burst": 5, "priority": 2},
+				{"pid": 2, "arrival": 3, "burst": 9, "priority": 1},
+				{"pid": 3, "arrival": 6, "burst": 6, "priority": 3}
+			]}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "malformed JSON",
+			method:     http.MethodPost,
+			body:       `{"processes": [`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no processes",
+			method:     http.MethodPost,
+			body:       `{"processes": []}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "zero burst",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 0}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			// the schedulers order the processes by arrival themselves
+			name:       "unsorted arrivals",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 4, "burst": 1}, {"pid": 2, "arrival": 0, "burst": 1}]}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "negative quantum",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"quantum": -1}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "zero round-robin quantum",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"rr_quanta": [2, 0]}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "setup on several CPUs",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"cpus": 2, "setup": {"default": 1}}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown tie-break",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"tie_break": "random"}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown option",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"quantom": 3}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "too many processes",
+			method:     http.MethodPost,
+			body:       `{"processes": [` + strings.Repeat(`{"pid": 1, "arrival": 0, "burst": 1},`, maxServeProcesses) + `{"pid": 1, "arrival": 0, "burst": 1}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "arrival too late",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 100001, "burst": 1}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "bursts too long",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 60000}, {"pid": 2, "arrival": 0, "burst": 60000}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "body too large",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"tie_break": "` + strings.Repeat("a", maxRequestBytes) + `"}}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "negative setup matrix time",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"setup": {"matrix": {"A": {"B": -1}}}}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "suspension ending before it starts",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"suspensions": [{"pid": 1, "from": 4, "to": 2}]}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "suspension of an unknown process",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"suspensions": [{"pid": 2, "from": 0, "to": 2}]}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "priority change of an unknown process",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"pid": 1, "arrival": 0, "burst": 1}], "options": {"priority_changes": [{"pid": 2, "time": 0, "priority": 1}]}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			// round-robin gives up on a suspension far longer than the work
+			name:   "scheduler fails",
+			method: http.MethodPost,
+			body: `{"processes": [{"pid": 1, "arrival": 0, "burst": 3}, {"pid": 2, "arrival": 1, "burst": 2}],
+				"options": {"suspensions": [{"pid": 2, "from": 1, "to": 100000}]}}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequest(tt.method, srv.URL+"/schedule", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+
+			var results []Result
+			if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if len(results) != len(schedulers) {
+				t.Fatalf("got %d results, want %d", len(results), len(schedulers))
+			}
+			for i, res := range results {
+				if res.Title != schedulers[i].title {
+					t.Errorf("result %d title = %q, want %q", i, res.Title, schedulers[i].title)
+				}
+				if len(res.Completion) != len(res.Processes) {
+					t.Errorf("%s: got %d completions, want %d", res.Title, len(res.Completion), len(res.Processes))
+				}
+			}
+		})
+	}
+
+	t.Run("options", func(t *testing.T) {
+		t.Parallel()
+		body := `{"processes": [
+			{"pid": 1, "arrival": 0, "burst": 5, "priority": 2},
+			{"pid": 2, "arrival": 3, "burst": 9, "priority": 1},
+			{"pid": 3, "arrival": 6, "burst": 6, "priority": 3}
+		], "options": {"quantum": 4}}`
+		resp, err := srv.Client().Post(srv.URL+"/schedule", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+		var results []Result
+		if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+
+		processes := []Process{
+			{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+			{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+		}
+		want := mustSchedule(t, RoundRobin, processes, Options{Quantum: 4})
+		rr := results[len(results)-1]
+		if !reflect.DeepEqual(rr.Gantt, want.Gantt) {
+			t.Errorf("round-robin Gantt = %v, want the quantum 4 schedule %v", rr.Gantt, want.Gantt)
+		}
+		if reflect.DeepEqual(rr.Gantt, mustSchedule(t, RoundRobin, processes, Options{}).Gantt) {
+			t.Errorf("round-robin Gantt = %v, the same as with the default quantum", rr.Gantt)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package scheduler
+
+import "fmt"
+
+// effectiveBursts returns a copy of processes with each burst, given in units of work,
+// converted to the ticks needed to finish it at the process's service rate, or at rate
+// for processes without one. Partial ticks round up: a process cannot finish early.
+func effectiveBursts(processes []Process, rate int64) ([]Process, error) {
+	if rate < 1 {
+		return nil, fmt.Errorf("%w: service rate %d, want >= 1", ErrInvalidArgs, rate)
+	}
+	out := make([]Process, len(processes))
+	for i, p := range processes {
+		r := rate
+		if p.Rate != 0 {
+			r = p.Rate
+		}
+		if r < 1 {
+			return nil, fmt.Errorf("%w: process %d has service rate %d, want >= 1", ErrInvalidArgs, p.ProcessID, r)
+		}
+		p.BurstDuration = (p.BurstDuration + r - 1) / r
+		out[i] = p
+	}
+
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_effectiveBursts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		rate      int64
+		want      []int64
+		wantErr   error
+	}{
+		{
+			name:      "per-process rate",
+			processes: []Process{{ProcessID: 1, BurstDuration: 10, Rate: 2}, {ProcessID: 2, BurstDuration: 4, ArrivalTime: 1}},
+			rate:      1,
+			want:      []int64{5, 4},
+		},
+		{
+			name:      "global rate",
+			processes: []Process{{ProcessID: 1, BurstDuration: 10}, {ProcessID: 2, BurstDuration: 9, Rate: 3}},
+			rate:      2,
+			want:      []int64{5, 3},
+		},
+		{
+			name:      "partial ticks round up",
+			processes: []Process{{ProcessID: 1, BurstDuration: 7, Rate: 2}},
+			rate:      1,
+			want:      []int64{4},
+		},
+		{
+			name:      "zero global rate",
+			processes: []Process{{ProcessID: 1, BurstDuration: 10}},
+			rate:      0,
+			wantErr:   ErrInvalidArgs,
+		},
+		{
+			name:      "negative process rate",
+			processes: []Process{{ProcessID: 1, BurstDuration: 10, Rate: -2}},
+			rate:      1,
+			wantErr:   ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := effectiveBursts(tt.processes, tt.rate)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("effectiveBursts() error = %v, want %v", err, tt.wantErr)
+			}
+			var bursts []int64
+			for _, p := range got {
+				bursts = append(bursts, p.BurstDuration)
+			}
+			if !reflect.DeepEqual(bursts, tt.want) {
+				t.Errorf("effectiveBursts() bursts = %v, want %v", bursts, tt.want)
+			}
+		})
+	}
+}
+
+func Test_effectiveBursts_schedule(t *testing.T) {
+	t.Parallel()
+	// 10 units of work at 2 per tick take 5 ticks
+	processes, err := effectiveBursts([]Process{{ProcessID: 1, BurstDuration: 10, Rate: 2}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range RunAll(processes, Options{}) {
+		want := []TimeSlice{{PID: 1, Start: 0, Stop: 5}}
+		if !reflect.DeepEqual(res.Gantt, want) {
+			t.Errorf("%s Gantt = %v, want %v", res.Title, res.Gantt, want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SetupTimes gives the time the CPU spends switching from a process of one class to a
+// process of another. Switching between processes of the same class is free.
+type SetupTimes struct {
+	// Default applies to any change of class not listed in Matrix.
+	Default int64 `json:"default,omitempty"`
+	// Matrix holds setup times by the previous class, then the next class.
+	Matrix map[string]map[string]int64 `json:"matrix,omitempty"`
+}
+
+// between returns the setup time for switching from class from to class to.
+func (s SetupTimes) between(from, to string) int64 {
+	if from == to {
+		return 0
+	}
+	if t, ok := s.Matrix[from][to]; ok {
+		return t
+	}
+
+	return s.Default
+}
+
+// parseSetupMatrix parses comma separated entries of the form from>to=time.
+func parseSetupMatrix(v string) (map[string]map[string]int64, error) {
+	matrix := make(map[string]map[string]int64)
+	for _, entry := range strings.Split(v, ",") {
+		classes, t, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: setup entry %q must look like from>to=time", ErrInvalidArgs, entry)
+		}
+		from, to, ok := strings.Cut(classes, ">")
+		if !ok {
+			return nil, fmt.Errorf("%w: setup entry %q must look like from>to=time", ErrInvalidArgs, entry)
+		}
+		setup, err := strconv.ParseInt(t, 10, 64)
+		if err != nil || setup < 0 {
+			return nil, fmt.Errorf("%w: setup entry %q needs a non-negative time", ErrInvalidArgs, entry)
+		}
+		if matrix[from] == nil {
+			matrix[from] = make(map[string]int64)
+		}
+		matrix[from][to] = setup
+	}
+
+	return matrix, nil
+}
@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_setupTimes(t *testing.T) {
+	t.Parallel()
+	twoClasses := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Class: "A"},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Class: "A"},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Class: "B"},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 2, Class: "B"},
+	}
+	// only the switch from P2 (class A) to P3 (class B) pays the setup time
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 3, Start: 7, Stop: 9},
+		{PID: 4, Start: 9, Stop: 11},
+	}
+	alternating := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Class: "A"},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Class: "B"},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Class: "A"},
+	}
+
+	tests := []struct {
+		name      string
+		schedule  scheduleFunc
+		processes []Process
+		setup     SetupTimes
+		want      []TimeSlice
+	}{
+		{name: "FCFS", schedule: infallible(FCFS), processes: twoClasses, setup: SetupTimes{Default: 3}, want: wantGantt},
+		{name: "SJF", schedule: infallible(SJF), processes: twoClasses, setup: SetupTimes{Default: 3}, want: wantGantt},
+		{name: "SJF event-driven", schedule: infallible(sjfEventDriven), processes: twoClasses, setup: SetupTimes{Default: 3}, want: wantGantt},
+		{name: "Priority", schedule: infallible(SJFPriority), processes: twoClasses, setup: SetupTimes{Default: 3}, want: wantGantt},
+		{name: "RR", schedule: RoundRobin, processes: twoClasses, setup: SetupTimes{Default: 3}, want: wantGantt},
+		{
+			name:      "matrix",
+			schedule:  infallible(FCFS),
+			processes: alternating,
+			setup: SetupTimes{Matrix: map[string]map[string]int64{
+				"A": {"B": 1},
+				"B": {"A": 4},
+			}},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 3, Stop: 5},
+				{PID: 3, Start: 9, Stop: 11},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := mustSchedule(t, tt.schedule, tt.processes, Options{Setup: tt.setup})
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseSetupMatrix(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		v       string
+		want    map[string]map[string]int64
+		wantErr error
+	}{
+		{
+			name: "success",
+			v:    "A>B=1, B>A=4,A>C=0",
+			want: map[string]map[string]int64{
+				"A": {"B": 1, "C": 0},
+				"B": {"A": 4},
+			},
+		},
+		{
+			name:    "missing time",
+			v:       "A>B",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "missing classes",
+			v:       "AB=3",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "negative time",
+			v:       "A>B=-1",
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSetupMatrix(tt.v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseSetupMatrix() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSetupMatrix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package scheduler
+
+import "math/rand"
+
+// shuffleProcesses returns a copy of processes in an order chosen by seed, to check that
+// the schedulers give the same results however the input is listed.
+func shuffleProcesses(processes []Process, seed int64) []Process {
+	out := append([]Process(nil), processes...)
+	rand.New(rand.NewSource(seed)).Shuffle(len(out), func(i, j int) {
+		out[i], out[j] = out[j], out[i]
+	})
+
+	return out
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+// timingsByPID returns each process's wait, turnaround and completion keyed by PID, so
+// results for the same processes listed in different orders can be compared.
+func timingsByPID(res Result) map[int64][3]int64 {
+	timings := make(map[int64][3]int64, len(res.Processes))
+	for i, p := range res.Processes {
+		timings[p.ProcessID] = [3]int64{res.Wait[i], res.Turnaround[i], res.Completion[i]}
+	}
+
+	return timings
+}
+
+func Test_shuffleProcesses(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 3, Priority: 3},
+		{ProcessID: 4, BurstDuration: 2, ArrivalTime: 10, Priority: 2},
+		{ProcessID: 5, BurstDuration: 4, ArrivalTime: 25, Priority: 1},
+	}
+	if !reflect.DeepEqual(shuffleProcesses(processes, 7), shuffleProcesses(processes, 7)) {
+		t.Fatal("shuffleProcesses() is not reproducible for the same seed")
+	}
+
+	tests := []struct {
+		name     string
+		schedule scheduleFunc
+	}{
+		{name: "FCFS", schedule: infallible(FCFS)},
+		{name: "SJF", schedule: infallible(SJF)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			want := mustSchedule(t, tt.schedule, processes, Options{})
+			for seed := int64(0); seed < 20; seed++ {
+				got := mustSchedule(t, tt.schedule, shuffleProcesses(processes, seed), Options{})
+				if !reflect.DeepEqual(got.Gantt, want.Gantt) {
+					t.Errorf("seed %d: Gantt = %v, want %v", seed, got.Gantt, want.Gantt)
+				}
+				if !reflect.DeepEqual(timingsByPID(got), timingsByPID(want)) {
+					t.Errorf("seed %d: timings = %v, want %v", seed, timingsByPID(got), timingsByPID(want))
+				}
+			}
+		})
+	}
+}
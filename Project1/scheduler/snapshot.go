@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// snapshotLines returns every comparison metric of each result, and each process's wait,
+// turnaround and completion, as "algorithm<TAB>metric<TAB>value" lines in sorted order.
+// Values are formatted at fixed precision, so equal results always give equal lines.
+func snapshotLines(results []Result) []string {
+	var lines []string
+	add := func(title, metric, value string) {
+		lines = append(lines, strings.Join([]string{title, metric, value}, "\t"))
+	}
+	for _, res := range results {
+		for _, group := range comparisonGroups {
+			for _, m := range group.metrics {
+				add(res.Title, snapshotKey(m.name), m.format(res))
+			}
+		}
+		for i, p := range res.Processes {
+			add(res.Title, fmt.Sprintf("p%d_wait", p.ProcessID), fmt.Sprint(res.Wait[i]))
+			add(res.Title, fmt.Sprintf("p%d_turnaround", p.ProcessID), fmt.Sprint(res.Turnaround[i]))
+			add(res.Title, fmt.Sprintf("p%d_completion", p.ProcessID), fmt.Sprint(res.Completion[i]))
+		}
+	}
+	sort.Strings(lines)
+
+	return lines
+}
+
+// snapshotKey turns a metric name such as "Average wait" into "average_wait".
+func snapshotKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
+
+// outputSnapshot writes the snapshot of results, one line each, ending with a newline.
+func outputSnapshot(w io.Writer, results []Result) {
+	for _, line := range snapshotLines(results) {
+		_, _ = fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func Test_run_snapshot(t *testing.T) {
+	t.Parallel()
+	var first, second bytes.Buffer
+	for _, out := range []*bytes.Buffer{&first, &second} {
+		if err := Run([]string{"scheduler", "-snapshot", "-with", "wfq", "../example_processes.csv"}, out); err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Fatalf("snapshots differ:\n%s\nand\n%s", first.String(), second.String())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(first.String(), "\n"), "\n")
+	if !sort.StringsAreSorted(lines) {
+		t.Errorf("snapshot lines are not sorted:\n%s", first.String())
+	}
+	for _, want := range []string{
+		"Shortest-job-first\taverage_wait\t2.67",
+		"First-come, first-serve\tp3_completion\t20",
+		"Weighted fair queuing\tmakespan\t20",
+	} {
+		if !strings.Contains(first.String(), want+"\n") {
+			t.Errorf("snapshot missing %q:\n%s", want, first.String())
+		}
+	}
+}
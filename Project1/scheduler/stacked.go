@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// stackedTickWidth is how many characters each tick takes in the stacked Gantt view.
+const stackedTickWidth = 3
+
+// stackedAxisStep is how many ticks apart the stacked Gantt view marks its time axis.
+const stackedAxisStep = 5
+
+// outputStackedGantt writes every result's Gantt chart as one row under a single header,
+// drawn to the same time scale so the rows line up, with one time axis shared below them.
+// Each slice is as wide as it is long, and ticks where the CPU was idle are dotted.
+func outputStackedGantt(w io.Writer, results []Result) {
+	var end int64
+	label := 0
+	for _, res := range results {
+		if n := len(res.Gantt); n > 0 && res.Gantt[n-1].Stop > end {
+			end = res.Gantt[n-1].Stop
+		}
+		if len(res.Title) > label {
+			label = len(res.Title)
+		}
+	}
+
+	outputTitle(w, "Stacked Gantt schedule")
+	for _, res := range results {
+		_, _ = fmt.Fprintf(w, "%-*s %s\n", label, res.Title, stackedBar(res.Gantt, end))
+	}
+	_, _ = fmt.Fprintf(w, "%-*s %s\n\n", label, "", stackedAxis(end))
+}
+
+// stackedBar draws gantt from time 0 to end, one stackedTickWidth cell per tick.
+func stackedBar(gantt []TimeSlice, end int64) string {
+	bar := []byte(strings.Repeat(".", int(end)*stackedTickWidth) + "|")
+	for _, slice := range gantt {
+		from, to := int(slice.Start)*stackedTickWidth, int(slice.Stop)*stackedTickWidth
+		bar[from] = '|'
+		for i := from + 1; i < to; i++ {
+			bar[i] = ' '
+		}
+		// center the PID in the slice, leaving it out if it does not fit
+		pid := fmt.Sprint(slice.PID)
+		if room := to - from - 1; len(pid) <= room {
+			copy(bar[from+1+(room-len(pid))/2:], pid)
+		}
+	}
+
+	return string(bar)
+}
+
+// stackedAxis labels every stackedAxisStep ticks, and end, under bars drawn by stackedBar.
+func stackedAxis(end int64) string {
+	axis := []byte(strings.Repeat(" ", int(end)*stackedTickWidth+1))
+	free := 0
+	mark := func(t int64) {
+		at := int(t) * stackedTickWidth
+		s := fmt.Sprint(t)
+		if at < free {
+			return
+		}
+		for len(axis) < at+len(s) {
+			axis = append(axis, ' ')
+		}
+		copy(axis[at:], s)
+		free = at + len(s) + 1
+	}
+	for t := int64(0); t < end; t += stackedAxisStep {
+		mark(t)
+	}
+	mark(end)
+
+	return strings.TrimRight(string(axis), " ")
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_outputStackedGantt(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+	}
+	results := RunAll(processes, Options{})
+	var out bytes.Buffer
+	outputStackedGantt(&out, results)
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 3+len(results)+1 {
+		t.Fatalf("got %d lines, want a header, a row per algorithm and an axis:\n%s", len(lines), out.String())
+	}
+
+	rows := lines[3 : 3+len(results)]
+	for i, res := range results {
+		if !strings.HasPrefix(rows[i], res.Title) {
+			t.Errorf("row %d = %q, want it labeled %s", i, rows[i], res.Title)
+		}
+		if bar := stackedBar(res.Gantt, 20); !strings.HasSuffix(rows[i], bar) {
+			t.Errorf("%s row = %q, want it to end with its Gantt %q", res.Title, rows[i], bar)
+		}
+		if len(rows[i]) != len(rows[0]) {
+			t.Errorf("%s row is %d wide, want %d like the others", res.Title, len(rows[i]), len(rows[0]))
+		}
+	}
+	// the axis marks the end of the schedule where every row ends
+	if axis := lines[len(lines)-1]; strings.LastIndex(axis, "20") != len(rows[0])-1 {
+		t.Errorf("axis = %q, want 20 under the end of the rows", axis)
+	}
+}
+
+func Test_stackedBar(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 12, Start: 3, Stop: 4}}
+	if got, want := stackedBar(gantt, 5), "|  1  ...|12...|"; got != want {
+		t.Errorf("stackedBar() = %q, want %q", got, want)
+	}
+}
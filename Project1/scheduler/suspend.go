@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+)
+
+// Suspension takes a process out of contention from From until To: it is not ready to
+// run during that time, even if it has arrived.
+type Suspension struct {
+	PID  int64 `json:"pid"`
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// isSuspended reports whether the process with the given ID is suspended at time.
+func isSuspended(suspensions []Suspension, pid, time int64) bool {
+	for _, s := range suspensions {
+		if s.PID == pid && s.From <= time && time < s.To {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resumeTime returns the first time from time onwards at which the process with the
+// given ID is not suspended.
+func resumeTime(suspensions []Suspension, pid, time int64) int64 {
+	for resumed := false; !resumed; {
+		resumed = true
+		for _, s := range suspensions {
+			if s.PID == pid && s.From <= time && time < s.To {
+				time, resumed = s.To, false
+			}
+		}
+	}
+
+	return time
+}
+
+// nextSuspension returns when the process with the given ID is next suspended after
+// time, or math.MaxInt64 if it never is.
+func nextSuspension(suspensions []Suspension, pid, time int64) int64 {
+	next := int64(math.MaxInt64)
+	for _, s := range suspensions {
+		if s.PID == pid && s.From > time && s.From < next {
+			next = s.From
+		}
+	}
+
+	return next
+}
+
+// nextSuspensionChange returns the next time after time at which any process is
+// suspended or resumed, or math.MaxInt64 if there is none.
+func nextSuspensionChange(suspensions []Suspension, time int64) int64 {
+	next := int64(math.MaxInt64)
+	for _, s := range suspensions {
+		if s.From > time && s.From < next {
+			next = s.From
+		}
+		if s.To > time && s.To < next {
+			next = s.To
+		}
+	}
+
+	return next
+}
+
+// suspendedFor returns how long the process with the given ID was suspended between
+// from and to. Overlapping suspensions are only counted once.
+func suspendedFor(suspensions []Suspension, pid, from, to int64) int64 {
+	var spans []Suspension
+	for _, s := range suspensions {
+		if s.PID == pid && s.From < to && s.To > from {
+			spans = append(spans, s)
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].From < spans[j].From })
+
+	var total int64
+	covered := from
+	for _, s := range spans {
+		start, stop := s.From, s.To
+		if start < covered {
+			start = covered
+		}
+		if stop > to {
+			stop = to
+		}
+		if stop > start {
+			total += stop - start
+			covered = stop
+		}
+	}
+
+	return total
+}
+
+// loadSuspensions reads suspensions from CSV rows of pid, from, to.
+func loadSuspensions(r io.Reader) ([]Suspension, error) {
+	rows, err := readIntRows(r, "suspension", "pid,from,to")
+	if err != nil {
+		return nil, err
+	}
+	suspensions := make([]Suspension, len(rows))
+	for i, row := range rows {
+		if row[2] < row[1] {
+			return nil, fmt.Errorf("%w: suspension row %d ends before it starts", ErrInvalidArgs, i+1)
+		}
+		suspensions[i] = Suspension{PID: row[0], From: row[1], To: row[2]}
+	}
+
+	return suspensions, nil
+}
+
+// openSuspensions loads the suspensions in the named file.
+func openSuspensions(name string) ([]Suspension, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening suspensions file", err)
+	}
+	defer f.Close()
+
+	return loadSuspensions(f)
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_suspensions(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 2},
+	}
+	suspensions := []Suspension{{PID: 1, From: 3, To: 6}}
+	all := append([]algorithm{{"SJF event-driven", infallible(sjfEventDriven)}, {"WFQ", infallible(wfq)}}, schedulers...)
+	for _, name := range []string{"sjf-np", "sjf-lookahead", "hrrn", "ljf", "mlfq"} {
+		all = append(all, extraSchedulers[name])
+	}
+
+	for _, s := range all {
+		s := s
+		t.Run(s.title, func(t *testing.T) {
+			t.Parallel()
+			got := mustSchedule(t, s.schedule, processes, Options{Suspensions: suspensions})
+			for _, slice := range got.Gantt {
+				if slice.PID == 1 && slice.Start < 6 && slice.Stop > 3 {
+					t.Errorf("P1 ran from %d to %d while suspended", slice.Start, slice.Stop)
+				}
+			}
+			for i, p := range processes {
+				// time suspended is not time spent waiting
+				want := got.Completion[i] - p.ArrivalTime - p.BurstDuration -
+					suspendedFor(suspensions, p.ProcessID, p.ArrivalTime, got.Completion[i])
+				if got.Wait[i] != want {
+					t.Errorf("P%d wait = %d, want %d", p.ProcessID, got.Wait[i], want)
+				}
+				if got.Turnaround[i] != got.Completion[i]-p.ArrivalTime {
+					t.Errorf("P%d turnaround = %d, want %d", p.ProcessID, got.Turnaround[i], got.Completion[i]-p.ArrivalTime)
+				}
+			}
+		})
+	}
+
+	tests := []struct {
+		name      string
+		schedule  scheduleFunc
+		wantGantt []TimeSlice
+		wantWait  []int64
+	}{
+		{
+			name:     "FCFS idles while the running process is suspended",
+			schedule: infallible(FCFS),
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 1, Start: 6, Stop: 8},
+				{PID: 2, Start: 8, Stop: 11},
+			},
+			wantWait: []int64{0, 7},
+		},
+		{
+			name:     "SJF runs the suspended process once it resumes",
+			schedule: infallible(SJF),
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 4},
+				{PID: 1, Start: 6, Stop: 10},
+			},
+			wantWait: []int64{2, 0},
+		},
+		{
+			name:     "non-preemptive SJF idles while the running process is suspended",
+			schedule: infallible(sjfNonPreemptive),
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 1, Start: 6, Stop: 8},
+				{PID: 2, Start: 8, Stop: 11},
+			},
+			wantWait: []int64{0, 7},
+		},
+		{
+			name:     "MLFQ passes over a suspended process in its queue",
+			schedule: infallible(mlfq),
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 2, Start: 4, Stop: 5},
+				{PID: 1, Start: 6, Stop: 9},
+			},
+			wantWait: []int64{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := mustSchedule(t, tt.schedule, processes, Options{Suspensions: suspensions})
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(got.Wait, tt.wantWait) {
+				t.Errorf("Wait = %v, want %v", got.Wait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func Test_suspendedFor(t *testing.T) {
+	t.Parallel()
+	suspensions := []Suspension{
+		{PID: 1, From: 2, To: 5},
+		{PID: 1, From: 4, To: 7},
+		{PID: 1, From: 10, To: 12},
+		{PID: 2, From: 0, To: 20},
+	}
+	tests := []struct {
+		name     string
+		from, to int64
+		want     int64
+	}{
+		{name: "overlapping suspensions count once", from: 0, to: 8, want: 5},
+		{name: "clipped to the span", from: 3, to: 11, want: 5},
+		{name: "no suspension in the span", from: 7, to: 10, want: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := suspendedFor(suspensions, 1, tt.from, tt.to); got != tt.want {
+				t.Errorf("suspendedFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadSuspensions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []Suspension
+		wantErr error
+	}{
+		{name: "success", input: "1,3,6\n2, 0, 4\n", want: []Suspension{{PID: 1, From: 3, To: 6}, {PID: 2, From: 0, To: 4}}},
+		{name: "ends before it starts", input: "1,6,3\n", wantErr: ErrInvalidArgs},
+		{name: "missing field", input: "1,6\n", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadSuspensions(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadSuspensions() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadSuspensions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
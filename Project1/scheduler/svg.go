@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// ganttFormats lists the formats accepted by -gantt-format.
+var ganttFormats = map[string]bool{"text": true, "svg": true}
+
+const (
+	// svgTickWidth is how many pixels wide each tick is drawn in an SVG Gantt chart.
+	svgTickWidth = 20
+	// svgBarHeight is the height in pixels of the bar of slices in an SVG Gantt chart.
+	svgBarHeight = 40
+	// svgMargin is the space in pixels around the bar, leaving room for the time axis.
+	svgMargin = 20
+)
+
+// writeGanttSVG writes gantt as an SVG document: a rectangle per slice, labelled with its
+// PID and as wide as it is long, with gaps where the CPU idled filled by gray rectangles
+// and the boundary of every slice marked on a time axis below. Time runs from the start of
+// the first slice.
+func writeGanttSVG(w io.Writer, gantt []TimeSlice) {
+	drawn := withIdleSlices(gantt)
+	var origin, end int64
+	if len(drawn) > 0 {
+		origin, end = drawn[0].Start, drawn[len(drawn)-1].Stop
+	}
+	x := func(t int64) int64 { return svgMargin + (t-origin)*svgTickWidth }
+
+	_, _ = fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="12">`+"\n",
+		x(end)+svgMargin, svgBarHeight+3*svgMargin)
+	for _, slice := range drawn {
+		fill := fmt.Sprintf("hsl(%d, 60%%, 70%%)", slice.PID*67%360)
+		if slice.PID == idlePID {
+			fill = "#ccc"
+		}
+		_, _ = fmt.Fprintf(w, `  <rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="black"/>`+"\n",
+			x(slice.Start), svgMargin, (slice.Stop-slice.Start)*svgTickWidth, svgBarHeight, fill)
+		_, _ = fmt.Fprintf(w, `  <text x="%d" y="%d" text-anchor="middle" dominant-baseline="middle">%s</text>`+"\n",
+			(x(slice.Start)+x(slice.Stop))/2, svgMargin+svgBarHeight/2, ganttLabel(slice.PID))
+		_, _ = fmt.Fprintf(w, `  <text x="%d" y="%d" text-anchor="middle">%d</text>`+"\n",
+			x(slice.Start), 2*svgMargin+svgBarHeight, slice.Start)
+	}
+	if len(drawn) > 0 {
+		_, _ = fmt.Fprintf(w, `  <text x="%d" y="%d" text-anchor="middle">%d</text>`+"\n",
+			x(end), 2*svgMargin+svgBarHeight, end)
+	}
+	_, _ = fmt.Fprintln(w, "</svg>")
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_writeGanttSVG(t *testing.T) {
+	t.Parallel()
+	// the CPU idles from 3 to 5
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 2, Start: 5, Stop: 7},
+		{PID: 12, Start: 7, Stop: 11},
+	}
+	var w bytes.Buffer
+	writeGanttSVG(&w, gantt)
+
+	// the document is well formed XML with an svg root holding a rect per slice and gap
+	var root string
+	var rects, idle int
+	d := xml.NewDecoder(&w)
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root == "" {
+			root = start.Name.Local
+		}
+		if start.Name.Local == "rect" {
+			rects++
+			for _, a := range start.Attr {
+				if a.Name.Local == "fill" && a.Value == "#ccc" {
+					idle++
+				}
+			}
+		}
+	}
+	if root != "svg" {
+		t.Errorf("root element = %q, want svg", root)
+	}
+	if rects != len(gantt)+1 || idle != 1 {
+		t.Errorf("got %d rects, %d of them idle, want %d with 1 idle", rects, idle, len(gantt)+1)
+	}
+}
+
+func Test_run_ganttFormat(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := Run([]string{"binary_name", "-gantt-format=svg", "-algorithm=fcfs", "../example_processes.csv"}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "<svg") || strings.Contains(out.String(), "Gantt schedule") {
+		t.Errorf("want an SVG chart in place of the text one:\n%s", out.String())
+	}
+
+	if err := Run([]string{"binary_name", "-gantt-format=png", "../example_processes.csv"}, io.Discard); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("run() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
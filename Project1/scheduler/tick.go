@@ -0,0 +1,30 @@
+package scheduler
+
+// onTicks calls opts.OnTick for every tick from start up to stop, with the same running
+// process (idlePID while the CPU idles, wakes or switches class) and ready set. The
+// ready set is only built when a hook is registered.
+func (o Options) onTicks(start, stop, pid int64, ready func() []int64) {
+	if o.OnTick == nil || start >= stop {
+		return
+	}
+	pids := ready()
+	for t := start; t < stop; t++ {
+		o.OnTick(t, pid, pids)
+	}
+}
+
+// readyPIDs returns a function listing the IDs of the processes, other than the running
+// one, that have arrived by time with work left and are not suspended.
+func readyPIDs(processes []Process, remaining []int64, opts Options, time int64, running int64) func() []int64 {
+	return func() []int64 {
+		ready := make([]int64, 0, len(processes))
+		for i, p := range processes {
+			if p.ArrivalTime <= time && remaining[i] > 0 && p.ProcessID != running &&
+				!isSuspended(opts.Suspensions, p.ProcessID, time) {
+				ready = append(ready, p.ProcessID)
+			}
+		}
+
+		return ready
+	}
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_onTick(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0, Priority: 1},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 5, Priority: 2},
+		{ProcessID: 3, BurstDuration: 4, ArrivalTime: 6, Priority: 1},
+	}
+	tests := []struct {
+		name      string
+		schedule  scheduleFunc
+		wantPIDs  []int64
+		wantReady map[int64][]int64
+	}{
+		{
+			name:      "SJF",
+			schedule:  infallible(SJF),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 2, 3, 3, 3, 3},
+			wantReady: map[int64][]int64{4: {}, 6: {3}, 7: {}},
+		},
+		{
+			name:      "Priority",
+			schedule:  infallible(SJFPriority),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 3, 3, 3, 3, 2},
+			wantReady: map[int64][]int64{5: {}, 6: {2}, 10: {}},
+		},
+		{
+			name:      "RR",
+			schedule:  RoundRobin,
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 2, 3, 3, 3, 3},
+			wantReady: map[int64][]int64{6: {3}, 8: {}},
+		},
+		{
+			name:      "SRTF",
+			schedule:  infallible(sjfEventDriven),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 2, 3, 3, 3, 3},
+			wantReady: map[int64][]int64{4: {}, 6: {3}, 7: {}},
+		},
+		{
+			// P3 arrives with more to do and preempts P2, which wins the tie at 9
+			name:      "LRTF",
+			schedule:  infallible(lrtf),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 3, 3, 3, 2, 3},
+			wantReady: map[int64][]int64{6: {2}, 9: {3}, 10: {}},
+		},
+		{
+			name:      "MLFQ",
+			schedule:  infallible(mlfq),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 2, 3, 3, 3, 3},
+			wantReady: map[int64][]int64{4: {}, 6: {3}, 7: {}},
+		},
+		{
+			name:      "EDF",
+			schedule:  infallible(edf),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 2, 3, 3, 3, 3},
+			wantReady: map[int64][]int64{4: {}, 6: {3}, 7: {}},
+		},
+		{
+			name:      "RM",
+			schedule:  infallible(rateMonotonic),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 2, 3, 3, 3, 3},
+			wantReady: map[int64][]int64{4: {}, 6: {3}, 7: {}},
+		},
+		{
+			name:      "WFQ",
+			schedule:  infallible(wfq),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 2, 3, 3, 3, 3},
+			wantReady: map[int64][]int64{4: {}, 6: {3}, 7: {}},
+		},
+		{
+			name:      "Lottery",
+			schedule:  infallible(lottery),
+			wantPIDs:  []int64{1, 1, 1, idlePID, idlePID, 2, 2, 3, 3, 3, 3},
+			wantReady: map[int64][]int64{4: {}, 6: {3}, 7: {}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				times []int64
+				pids  []int64
+				ready = make(map[int64][]int64)
+			)
+			res := mustSchedule(t, tt.schedule, processes, Options{OnTick: func(time, runningPID int64, r []int64) {
+				times = append(times, time)
+				pids = append(pids, runningPID)
+				ready[time] = r
+			}})
+
+			// called once per tick, in order, up to the end of the schedule
+			for i, time := range times {
+				if time != int64(i) {
+					t.Fatalf("OnTick times = %v, want 0 to %d in order", times, len(times)-1)
+				}
+			}
+			if end := res.Gantt[len(res.Gantt)-1].Stop; int64(len(times)) != end {
+				t.Errorf("OnTick called %d times, want %d", len(times), end)
+			}
+			if !reflect.DeepEqual(pids, tt.wantPIDs) {
+				t.Errorf("running PIDs = %v, want %v", pids, tt.wantPIDs)
+			}
+			for time, want := range tt.wantReady {
+				if !reflect.DeepEqual(ready[time], want) {
+					t.Errorf("ready at %d = %v, want %v", time, ready[time], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// writeTrace writes a line for every tick of res, from the first arrival until the last
+// process completes, giving the process running and the processes ready to run: arrived,
+// not yet complete and not suspended. It is reconstructed from the Gantt chart and
+// completion times, so it works for every algorithm alike.
+func writeTrace(w io.Writer, res Result, suspensions []Suspension) {
+	if len(res.Processes) == 0 {
+		return
+	}
+	start, end := res.Processes[0].ArrivalTime, int64(0)
+	for i, p := range res.Processes {
+		if p.ArrivalTime < start {
+			start = p.ArrivalTime
+		}
+		if res.Completion[i] > end {
+			end = res.Completion[i]
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Trace of %s\n", res.Title)
+	slice := 0
+	for t := start; t < end; t++ {
+		for slice < len(res.Gantt) && res.Gantt[slice].Stop <= t {
+			slice++
+		}
+		running := int64(idlePID)
+		if slice < len(res.Gantt) && res.Gantt[slice].Start <= t {
+			running = res.Gantt[slice].PID
+		}
+
+		var ready []string
+		for i, p := range res.Processes {
+			if p.ProcessID != running && p.ArrivalTime <= t && res.Completion[i] > t &&
+				!isSuspended(suspensions, p.ProcessID, t) {
+				ready = append(ready, fmt.Sprintf("P%d", p.ProcessID))
+			}
+		}
+		if running == idlePID {
+			_, _ = fmt.Fprintf(w, "t=%d: idle, ready {%s}\n", t, strings.Join(ready, ","))
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "t=%d: running P%d, ready {%s}\n", t, running, strings.Join(ready, ","))
+	}
+}
@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_writeTrace(t *testing.T) {
+	t.Parallel()
+	// P2 arrives while P1 runs, then the CPU idles until P3 arrives
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 7},
+	}
+	var trace bytes.Buffer
+	runAlgorithms([]algorithm{{"First-come, first-serve", infallible(FCFS)}}, processes, Options{Trace: &trace})
+
+	want := []string{
+		"Trace of First-come, first-serve",
+		"t=0: running P1, ready {}",
+		"t=1: running P1, ready {P2}",
+		"t=2: running P1, ready {P2}",
+		"t=3: running P2, ready {}",
+		"t=4: running P2, ready {}",
+		"t=5: idle, ready {}",
+		"t=6: idle, ready {}",
+		"t=7: running P3, ready {}",
+	}
+	if got := strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("trace = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Share compares the CPU a process received with the share its weight entitles it to.
+type Share struct {
+	PID    int64 `json:"pid"`
+	Weight int64 `json:"weight"`
+	// Entitled is the process's weight as a fraction of the total weight.
+	Entitled float64 `json:"entitled"`
+	// Realized is the fraction of the CPU the process received while every process was
+	// in the system.
+	Realized float64 `json:"realized"`
+}
+
+// WFQSchedule writes the weighted fair queuing schedule of the processes to w.
+func WFQSchedule(w io.Writer, title string, processes []Process) Result {
+	res := wfq(processes, Options{})
+	outputResult(w, title, res, config{})
+
+	return res
+}
+
+// wfq approximates generalized processor sharing with weighted fair queuing, treating
+// every tick of a process as a packet. Each packet is tagged with a virtual finish time
+// one tick of weighted service after the previous packet of the same process, or after
+// the current virtual time for a process that has just arrived, and the packet with the
+// earliest tag is served first. The virtual time is the tag of the last packet served.
+// Ties go to the process listed first.
+func wfq(processes []Process, opts Options) Result {
+	var (
+		time        int64
+		virtual     int64
+		finished    int
+		last        = -1
+		gantt       = make([]TimeSlice, 0)
+		remaining   = make([]int64, len(processes))
+		finish      = make([]int64, len(processes))
+		tagged      = make([]bool, len(processes))
+		step        = make([]int64, len(processes))
+		waitTimes   = make([]int64, len(processes))
+		turnArounds = make([]int64, len(processes))
+		completions = make([]int64, len(processes))
+	)
+
+	// tags are kept in units of 1/scale so every step is a whole number
+	scale := int64(1)
+	for i := range processes {
+		scale = lcm(scale, processes[i].weight())
+	}
+	for i := range processes {
+		remaining[i] = processes[i].cpuTime()
+		step[i] = scale / processes[i].weight()
+	}
+	if len(processes) > 0 {
+		time = dispatchStart(processes, opts.DispatchLatency)
+	}
+
+	for finished != len(processes) {
+		for i := range processes {
+			if !tagged[i] && processes[i].ArrivalTime <= time {
+				finish[i] = virtual + step[i]
+				tagged[i] = true
+			}
+		}
+
+		// serve the packet with the earliest virtual finish time
+		next := -1
+		for i := range processes {
+			if isSuspended(opts.Suspensions, processes[i].ProcessID, time) {
+				continue
+			}
+			if tagged[i] && remaining[i] > 0 && (next == -1 || finish[i] < finish[next]) {
+				next = i
+			}
+		}
+		if next == -1 {
+			opts.onTicks(time, time+1, idlePID, readyPIDs(processes, remaining, opts, time, idlePID))
+			time++
+			continue
+		}
+
+		if opts.Explain != nil && isDispatch(gantt, processes[next].ProcessID, time) {
+			explainDispatch(opts.Explain, time, processes[next].ProcessID,
+				fmt.Sprintf("earliest virtual finish (%d/%d)", finish[next], scale),
+				readyLabels(processes, remaining, time, func(i int) string {
+					return fmt.Sprintf("P%d:%d/%d", processes[i].ProcessID, finish[i], scale)
+				}))
+		}
+
+		// a powered down CPU has to wake, and switching class costs setup time,
+		// before the process can run
+		dispatched := time
+		time += opts.PowerDown.wakeDelay(gantt, time)
+		if last != -1 {
+			time += opts.Setup.between(processes[last].Class, processes[next].Class)
+		}
+		last = next
+		opts.onTicks(dispatched, time, idlePID, readyPIDs(processes, remaining, opts, dispatched, idlePID))
+
+		pid := processes[next].ProcessID
+		opts.onTicks(time, time+1, pid, readyPIDs(processes, remaining, opts, time, pid))
+		gantt = runTick(gantt, pid, time)
+		remaining[next]--
+		time++
+		virtual = finish[next]
+		finish[next] += step[next]
+
+		if remaining[next] == 0 {
+			finished++
+			completions[next] = time
+			turnArounds[next] = time - processes[next].ArrivalTime
+			waitTimes[next] = turnArounds[next] - processes[next].cpuTime() -
+				suspendedFor(opts.Suspensions, processes[next].ProcessID, processes[next].ArrivalTime, time)
+		}
+	}
+
+	return Result{
+		Processes:  processes,
+		Wait:       waitTimes,
+		Turnaround: turnArounds,
+		Completion: completions,
+		Gantt:      gantt,
+		Throughput: throughput(processes, gantt),
+		Shares:     shares(processes, gantt, completions),
+	}
+}
+
+// weight returns the process's weight, counting an unset weight as 1.
+func (p Process) weight() int64 {
+	if p.Weight <= 0 {
+		return 1
+	}
+
+	return p.Weight
+}
+
+// shares compares each process's weight with the CPU it received while every process was
+// in the system: from the last arrival until the first completion. It returns nil if some
+// process completed before the last one arrived.
+func shares(processes []Process, gantt []TimeSlice, completions []int64) []Share {
+	if len(processes) == 0 {
+		return nil
+	}
+	from, to := processes[0].ArrivalTime, completions[0]
+	var totalWeight int64
+	for i := range processes {
+		if processes[i].ArrivalTime > from {
+			from = processes[i].ArrivalTime
+		}
+		if completions[i] < to {
+			to = completions[i]
+		}
+		totalWeight += processes[i].weight()
+	}
+	if to <= from {
+		return nil
+	}
+
+	received := make(map[int64]int64)
+	for _, slice := range gantt {
+		start, stop := slice.Start, slice.Stop
+		if start < from {
+			start = from
+		}
+		if stop > to {
+			stop = to
+		}
+		if stop > start {
+			received[slice.PID] += stop - start
+		}
+	}
+
+	out := make([]Share, len(processes))
+	for i := range processes {
+		out[i] = Share{
+			PID:      processes[i].ProcessID,
+			Weight:   processes[i].weight(),
+			Entitled: float64(processes[i].weight()) / float64(totalWeight),
+			Realized: float64(received[processes[i].ProcessID]) / float64(to-from),
+		}
+	}
+
+	return out
+}
+
+// lcm returns the least common multiple of two positive numbers.
+func lcm(a, b int64) int64 {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+
+	return a / x * b
+}
+
+// outputShares writes each process's entitled and realized share of the CPU.
+func outputShares(w io.Writer, shares []Share) {
+	_, _ = fmt.Fprintln(w, "CPU shares")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Weight", "Entitled", "Realized"})
+	for _, s := range shares {
+		table.Append([]string{
+			fmt.Sprint(s.PID),
+			fmt.Sprint(s.Weight),
+			fmt.Sprintf("%.2f", s.Entitled),
+			fmt.Sprintf("%.2f", s.Realized),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_wfq(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 12, Weight: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 12, Weight: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 12, Weight: 3},
+	}
+	got := wfq(processes, Options{})
+
+	// heavier processes finish first
+	for i := 1; i < len(processes); i++ {
+		if got.Completion[i] >= got.Completion[i-1] {
+			t.Errorf("P%d (weight %d) completed at %d, not before P%d (weight %d) at %d",
+				processes[i].ProcessID, processes[i].Weight, got.Completion[i],
+				processes[i-1].ProcessID, processes[i-1].Weight, got.Completion[i-1])
+		}
+	}
+
+	// and receive the CPU in proportion to their weights
+	if len(got.Shares) != len(processes) {
+		t.Fatalf("got %d shares, want %d", len(got.Shares), len(processes))
+	}
+	for i, s := range got.Shares {
+		if want := float64(processes[i].Weight) / 6; math.Abs(s.Entitled-want) > 1e-9 {
+			t.Errorf("P%d entitled = %.2f, want %.2f", s.PID, s.Entitled, want)
+		}
+		if math.Abs(s.Realized-s.Entitled) > 0.05 {
+			t.Errorf("P%d realized = %.2f, want about %.2f", s.PID, s.Realized, s.Entitled)
+		}
+	}
+}
+
+func Test_wfq_equalWeights(t *testing.T) {
+	t.Parallel()
+	// unset weights count as 1, so the CPU alternates between the two
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+	}
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 2},
+		{PID: 1, Start: 2, Stop: 3},
+		{PID: 2, Start: 3, Stop: 4},
+	}
+	if got := wfq(processes, Options{}); !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", got.Gantt, want)
+	}
+}
+
+func Test_parseArgs_with(t *testing.T) {
+	t.Parallel()
+	cfg, _, err := parseArgs("binary_name", "-with", "wfq", "../example_processes.csv")
+	if err != nil {
+		t.Fatalf("parseArgs() error = %v", err)
+	}
+	if len(cfg.extra) != 1 || cfg.extra[0].title != "Weighted fair queuing" {
+		t.Errorf("extra = %v, want weighted fair queuing", cfg.extra)
+	}
+	if err := Run([]string{"binary_name", "-with", "stride", "../example_processes.csv"}, io.Discard); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("run() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,264 @@
+package scheduler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// inputLoaders parses a scheduling file in each format accepted by -input-format.
+var inputLoaders = map[string]func(r io.Reader) ([]Process, error){
+	"csv":  LoadProcesses,
+	"xlsx": loadProcessesXLSX,
+	"json": loadProcessesJSON,
+}
+
+// loadInput parses the scheduling file read from r in the given format. CSV fields hold
+// the given columns, or are in CSV order if columns is nil.
+func loadInput(r io.Reader, format string, columns []int) ([]Process, error) {
+	if columns != nil {
+		if format != "csv" {
+			return nil, fmt.Errorf("%w: -columns only applies to csv input", ErrInvalidArgs)
+		}
+		return loadProcessesInOrder(r, columns)
+	}
+	load, ok := inputLoaders[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown input format %q", ErrInvalidArgs, format)
+	}
+
+	return load(r)
+}
+
+// xlsxColumns maps a normalized xlsx header or -columns name to the CSV column it holds.
+var xlsxColumns = map[string]int{
+	"pid":           0,
+	"id":            0,
+	"processid":     0,
+	"burst":         1,
+	"burstduration": 1,
+	"arrival":       2,
+	"arrivaltime":   2,
+	"priority":      3,
+	"deadline":      4,
+	"class":         5,
+	"weight":        6,
+	"rate":          7,
+	"quota":         8,
+	"period":        9,
+	"tickets":       10,
+}
+
+// xlsxRequired names the columns an xlsx file must have, in CSV column order.
+var xlsxRequired = []string{"pid", "burst", "arrival"}
+
+// loadProcessesXLSX reads processes from the first sheet of an xlsx workbook. The first
+// row is a header naming each column; columns are matched by name in any order, unknown
+// columns are ignored, and missing optional columns default to zero.
+func loadProcessesXLSX(r io.Reader) ([]Process, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading xlsx", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading xlsx", err)
+	}
+	rows, err := readFirstSheet(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading xlsx", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	// find the CSV column for each header cell
+	columns := make([]int, len(rows[0]))
+	var found [processColumns]bool
+	for i, name := range rows[0] {
+		col, ok := columnNamed(name)
+		if !ok {
+			columns[i] = -1
+			continue
+		}
+		columns[i] = col
+		found[col] = true
+	}
+	for col, name := range xlsxRequired {
+		if !found[col] {
+			return nil, fmt.Errorf("%w: xlsx header has no %q column", ErrInvalidArgs, name)
+		}
+	}
+
+	// lay each row out in CSV column order
+	csvRows := make([][]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		csvRows = append(csvRows, reorderRow(row, columns))
+	}
+
+	// the header is the first row of the sheet
+	return processesFromRows(csvRows, 2)
+}
+
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxSharedStrings struct {
+		Items []struct {
+			Text string   `xml:"t"`
+			Runs []string `xml:"r>t"`
+		} `xml:"si"`
+	}
+	xlsxSheet struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+// readFirstSheet returns the text of every non-empty row in the workbook's first sheet.
+func readFirstSheet(zr *zip.Reader) ([][]string, error) {
+	var wb xlsxWorkbook
+	if err := decodeZipXML(zr, "xl/workbook.xml", &wb); err != nil {
+		return nil, err
+	}
+	if len(wb.Sheets) == 0 {
+		return nil, fmt.Errorf("%w: workbook has no sheets", ErrInvalidArgs)
+	}
+	var rels xlsxRelationships
+	if err := decodeZipXML(zr, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	var sheetPath string
+	for _, rel := range rels.Relationships {
+		if rel.ID == wb.Sheets[0].RelID {
+			// targets are relative to xl/ unless they start from the package root
+			sheetPath = path.Join("xl", rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPath = strings.TrimPrefix(rel.Target, "/")
+			}
+		}
+	}
+	if sheetPath == "" {
+		return nil, fmt.Errorf("%w: first sheet %q has no relationship", ErrInvalidArgs, wb.Sheets[0].RelID)
+	}
+
+	// workbooks without text cells have no shared strings
+	var shared xlsxSharedStrings
+	if err := decodeZipXML(zr, "xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	strs := make([]string, len(shared.Items))
+	for i, item := range shared.Items {
+		strs[i] = item.Text + strings.Join(item.Runs, "")
+	}
+
+	var sheet xlsxSheet
+	if err := decodeZipXML(zr, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		var fields []string
+		for i, c := range row.Cells {
+			// empty cells may be left out, so place each cell by its reference
+			col := i
+			if c.Ref != "" {
+				if col = columnIndex(c.Ref); col < 0 {
+					return nil, fmt.Errorf("%w: bad cell reference %q", ErrInvalidArgs, c.Ref)
+				}
+			}
+			for len(fields) <= col {
+				fields = append(fields, "")
+			}
+			v := c.Value
+			switch c.Type {
+			case "s":
+				var idx int
+				if _, err := fmt.Sscan(v, &idx); err != nil || idx < 0 || idx >= len(strs) {
+					return nil, fmt.Errorf("%w: cell %s has bad shared string %q", ErrInvalidArgs, c.Ref, v)
+				}
+				v = strs[idx]
+			case "inlineStr":
+				v = c.Inline
+			case "", "n":
+				v = wholeNumber(v)
+			}
+			fields[col] = strings.TrimSpace(v)
+		}
+		if strings.Join(fields, "") != "" {
+			rows = append(rows, fields)
+		}
+	}
+
+	return rows, nil
+}
+
+// decodeZipXML decodes the named XML file in the archive into v.
+func decodeZipXML(zr *zip.Reader, name string, v any) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := xml.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("%w: decoding %s", err, name)
+	}
+
+	return nil
+}
+
+// xlsxMaxColumns is how many columns a sheet can have, up to column XFD.
+const xlsxMaxColumns = 16384
+
+// columnIndex returns the zero-based column of a cell reference such as "C7", or -1 if it
+// names no column or one past the last a sheet can have.
+func columnIndex(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A') + 1
+		if col > xlsxMaxColumns {
+			return -1
+		}
+	}
+
+	return col - 1
+}
+
+// wholeNumber returns a numeric cell's value written as an integer if it is a whole
+// number, since spreadsheets store every number as a float and may write 5 as "5.0" or
+// "5E0". Any other value is returned as it is.
+func wholeNumber(v string) string {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return v
+	}
+
+	return strconv.FormatInt(int64(f), 10)
+}
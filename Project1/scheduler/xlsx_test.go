@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildXLSX returns a minimal workbook whose first sheet holds rows. Numeric cells are
+// stored as numbers, the header as shared strings and any other text inline, and
+// empty cells are left out as spreadsheet programs do.
+func buildXLSX(t *testing.T, rows [][]string) []byte {
+	t.Helper()
+	var shared []string
+	var sheet strings.Builder
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range rows {
+		fmt.Fprintf(&sheet, `<row r="%d">`, r+1)
+		for c, v := range row {
+			ref := fmt.Sprintf("%c%d", 'A'+c, r+1)
+			var n int64
+			switch _, err := fmt.Sscan(v, &n); {
+			case v == "":
+			case r == 0:
+				fmt.Fprintf(&sheet, `<c r="%s" t="s"><v>%d</v></c>`, ref, len(shared))
+				shared = append(shared, v)
+			case err == nil:
+				fmt.Fprintf(&sheet, `<c r="%s"><v>%s</v></c>`, ref, v)
+			default:
+				fmt.Fprintf(&sheet, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, v)
+			}
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var sst strings.Builder
+	sst.WriteString(`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	for _, s := range shared {
+		fmt.Fprintf(&sst, `<si><t>%s</t></si>`, s)
+	}
+	sst.WriteString(`</sst>`)
+
+	files := []struct{ name, body string }{
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Processes" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`},
+		{"xl/sharedStrings.xml", sst.String()},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(f.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return b.Bytes()
+}
+
+func Test_loadProcessesXLSX(t *testing.T) {
+	t.Parallel()
+	want, err := LoadProcesses(strings.NewReader("1,5,0,2,0,A\n2,9,3,1,0,\n3,6,6,3,0,B\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		rows    [][]string
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "columns in CSV order",
+			rows: [][]string{
+				{"pid", "burst", "arrival", "priority", "deadline", "class"},
+				{"1", "5", "0", "2", "0", "A"},
+				{"2", "9", "3", "1", "0", ""},
+				{"3", "6", "6", "3", "0", "B"},
+			},
+			want: want,
+		},
+		{
+			name: "columns matched by header, extra and missing ones",
+			rows: [][]string{
+				{"Arrival Time", "Process ID", "Notes", "Burst", "Class", "Priority"},
+				{"0", "1", "first", "5", "A", "2"},
+				{"3", "2", "", "9", "", "1"},
+				{"6", "3", "last", "6", "B", "3"},
+			},
+			want: want,
+		},
+		{
+			name: "numbers written as floats",
+			rows: [][]string{
+				{"pid", "burst", "arrival", "priority", "deadline", "class"},
+				{"1.0", "5.0", "0", "2", "0", "A"},
+				{"2", "9E0", "3.0", "1", "0", ""},
+				{"3", "6", "6", "3.00", "0", "B"},
+			},
+			want: want,
+		},
+		{
+			name: "fractional burst",
+			rows: [][]string{
+				{"pid", "burst", "arrival"},
+				{"1", "5.5", "0"},
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "missing burst column",
+			rows: [][]string{
+				{"pid", "arrival"},
+				{"1", "0"},
+			},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadProcessesXLSX(bytes.NewReader(buildXLSX(t, tt.rows)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadProcessesXLSX() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProcessesXLSX() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_columnIndex(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ref  string
+		want int
+	}{
+		{ref: "A1", want: 0},
+		{ref: "C7", want: 2},
+		{ref: "AA3", want: 26},
+		{ref: "XFD1", want: xlsxMaxColumns - 1},
+		{ref: "XFE1", want: -1},
+		{ref: strings.Repeat("Z", 20) + "1", want: -1},
+		{ref: "7", want: -1},
+	}
+	for _, tt := range tests {
+		if got := columnIndex(tt.ref); got != tt.want {
+			t.Errorf("columnIndex(%q) = %d, want %d", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func Test_run_inputXLSX(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "processes.xlsx")
+	xlsx := buildXLSX(t, [][]string{
+		{"pid", "burst", "arrival", "priority"},
+		{"1", "5", "0", "2"},
+		{"2", "9", "3", "1"},
+		{"3", "6", "6", "3"},
+	})
+	if err := os.WriteFile(name, xlsx, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var got, want bytes.Buffer
+	if err := Run([]string{"scheduler", "-input-format", "xlsx", name}, &got); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if err := Run([]string{"scheduler", "../example_processes.csv"}, &want); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("xlsx output differs from the CSV output:\n%s\nwant\n%s", got.String(), want.String())
+	}
+}